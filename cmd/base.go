@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/phillippbertram/xc-strings/internal"
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+// resolveLocalizationSet groups the files of the manager into tables and locales and designates the base of each table.
+// An explicitly given base file determines the base locale. Otherwise the development region of the
+// Xcode project or Swift package above the working directory is used.
+func resolveLocalizationSet(manager *localizable.StringsFileManager, basePath string) (*localizable.LocalizationSet, error) {
+	if basePath != "" {
+		baseFile := manager.GetFile(basePath)
+		files := manager.Files
+		if baseFile == nil {
+			// the base file may live outside of the scanned strings path
+			var err error
+			baseFile, err = localizable.NewStringsFile(basePath)
+			if err != nil {
+				return nil, fmt.Errorf("error reading base strings file: %w", err)
+			}
+			files = append([]*localizable.StringsFile{baseFile}, files...)
+		}
+
+		return localizable.NewLocalizationSetWithBase(files, baseFile), nil
+	}
+
+	project, err := internal.FindProject(".")
+	if err != nil {
+		return nil, fmt.Errorf("no base strings file specified (-b): %w", err)
+	}
+
+	set := manager.LocalizationSet(project.DevelopmentRegion)
+	baseFiles := set.BaseFiles()
+	if len(baseFiles) == 0 {
+		return nil, fmt.Errorf("no strings files found for base language '%s' of %s", project.DevelopmentRegion, project.Path)
+	}
+
+	fmt.Printf("Using base language '%s' from %s\n", project.DevelopmentRegion, project.Path)
+	for _, file := range baseFiles {
+		fmt.Printf("Base file for table %s: %s\n", file.Table(), file.Path)
+	}
+
+	return set, nil
+}
+
+// codeReferencedFiles filters out tables whose keys are not referenced in code, like InfoPlist.strings.
+func codeReferencedFiles(files []*localizable.StringsFile) []*localizable.StringsFile {
+	result := make([]*localizable.StringsFile, 0, len(files))
+	for _, file := range files {
+		if file.IsReferencedInCode() {
+			result = append(result, file)
+		}
+	}
+	return result
+}
+
+// keysForFiles returns the sorted unique keys of all given files
+func keysForFiles(files []*localizable.StringsFile) []string {
+	keys := make(map[string]struct{})
+	for _, file := range files {
+		for _, key := range file.GetAllKeys() {
+			keys[key] = struct{}{}
+		}
+	}
+
+	result := internal.MapToSlice(keys)
+	sort.Strings(result)
+	return result
+}
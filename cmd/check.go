@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -10,37 +11,30 @@ import (
 	"github.com/fatih/color"
 
 	"github.com/phillippbertram/xc-strings/internal"
+	"github.com/phillippbertram/xc-strings/internal/baseline"
 	"github.com/phillippbertram/xc-strings/internal/constants"
 	"github.com/phillippbertram/xc-strings/internal/localizable"
+	"github.com/phillippbertram/xc-strings/internal/rules"
 
 	"github.com/spf13/cobra"
 )
 
-// Define constants for the different checks to avoid hardcoded strings
-const (
-	CheckSorting     = "sorting"
-	CheckDuplicates  = "duplicates"
-	CheckEmptyValues = "emptyValues"
-	CheckUnused      = "unused"
-)
-
-// Define a list of all available checks
-var allChecks = []string{
-	CheckSorting,
-	CheckDuplicates,
-	CheckEmptyValues,
-	CheckUnused,
-}
-
 // Define options for different checks and flags
 type CheckOptions struct {
-	exitOnIssue     bool
-	stringsPath     string
-	swiftDirectory  string
-	baseStringsPath string
-	ignorePatterns  []string
-	includeChecks   []string
-	excludeChecks   []string
+	exitOnIssue      bool
+	stringsPaths     []string
+	swiftDirectories []string
+	baseStringsPath  string
+	ignorePatterns   []string
+	includeChecks    []string
+	excludeChecks    []string
+	perTarget        bool
+	listRules        bool
+	format           string
+	baselinePath     string
+	updateBaseline   bool
+	fix              bool
+	dryRun           bool
 }
 
 // Initialize the CheckOptions struct
@@ -52,9 +46,12 @@ var checkCmd = &cobra.Command{
 	Use:   "check -b [path to base strings file] -d [path to Swift directory] [path to strings file(s)]",
 	Short: "Check for issues in .strings files",
 	Example: heredoc.Doc(`
-		# Run all checks (sorting, duplicates, empty values, unused keys):
+		# Run the default checks and the opt-in checks configured in .xcstrings.yml:
 		$ ./xcs check
 
+		# List all available checks:
+		$ ./xcs check --list
+
 		# Include only sorting and duplicates checks:
 		$ ./xcs check --include sorting --include duplicates
 
@@ -63,139 +60,188 @@ var checkCmd = &cobra.Command{
 
 		# Exclude both sorting and duplicate checks:
 		$ ./xcs check --exclude sorting --exclude duplicates
+
+		# Check each Xcode or Swift package target separately:
+		$ ./xcs check Packages --targets
+
+		# Write a SARIF report for GitHub code scanning:
+		$ ./xcs check --format sarif > xcs.sarif
+
+		# Fix sorting and duplicates first, then report the remaining issues:
+		$ ./xcs check --fix
+
+		# Accept all current issues and only report new ones from now on:
+		$ ./xcs check --update-baseline
+		$ ./xcs check
 	`),
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 
-		if len(args) > 0 {
-			checkOptions.stringsPath = args[0]
-		} else {
-			checkOptions.stringsPath = constants.DefaultStringsGlob
+		if checkOptions.listRules {
+			printRules()
+			return nil
 		}
 
-		// Check if the unused key check requires a base strings file
-		if checkOptions.baseStringsPath == "" && contains(checkOptions.includeChecks, CheckUnused) {
-			return fmt.Errorf("base Localizable.strings file is required for unused key check")
-		}
-
-		// Set a default swift directory if not specified
-		if checkOptions.swiftDirectory == "" {
-			checkOptions.swiftDirectory = "."
-		}
+		checkOptions.stringsPaths = stringsPathsFromArgs(args, []string{constants.DefaultStringsGlob})
+		configString(cmd, "base", &checkOptions.baseStringsPath, projectConfig.BasePath())
+		configStrings(cmd, "swift-dir", &checkOptions.swiftDirectories, projectConfig.SourcePaths())
+		configStrings(cmd, "ignore", &checkOptions.ignorePatterns, projectConfig.IgnorePatterns())
 
-		// Ensure that both `--include` and `--exclude` are not used simultaneously
-		if len(checkOptions.includeChecks) > 0 && len(checkOptions.excludeChecks) > 0 {
-			return fmt.Errorf("you cannot use both --include and --exclude flags at the same time")
+		// Select the rules to run based on `--include` and `--exclude`
+		selectedRules, err := rules.Select(checkOptions.includeChecks, checkOptions.excludeChecks, projectConfig)
+		if err != nil {
+			return err
 		}
 
-		// Create a map to track the active status of each check
-		activeChecks := make(map[string]bool)
-		for _, check := range allChecks {
-			activeChecks[check] = true // Enable all checks by default
-		}
+		// Rules turned off in the project configuration only run if they are included explicitly
+		selectedRules = withoutDisabledRules(selectedRules, checkOptions.includeChecks)
 
-		// If `--include` is used, only enable the specified checks
-		if len(checkOptions.includeChecks) > 0 {
-			// Disable all checks first
-			for _, check := range allChecks {
-				activeChecks[check] = false
-			}
-			// Enable only the included checks
-			for _, includeCheck := range checkOptions.includeChecks {
-				if _, ok := activeChecks[includeCheck]; ok {
-					activeChecks[includeCheck] = true
-				} else {
-					return fmt.Errorf("unknown check to include: %s", includeCheck)
+		// Fix what can be fixed automatically before checking the files
+		if checkOptions.fix {
+			var fixable []rules.Rule
+			for _, rule := range selectedRules {
+				if rules.IsFixable(rule) {
+					fixable = append(fixable, rule)
 				}
 			}
+			if err := applyFixes(checkOptions.stringsPaths, fixable, checkOptions.dryRun); err != nil {
+				return err
+			}
 		}
 
-		// If `--exclude` is used, disable the specified checks
-		if len(checkOptions.excludeChecks) > 0 {
-			for _, excludeCheck := range checkOptions.excludeChecks {
-				if _, ok := activeChecks[excludeCheck]; ok {
-					activeChecks[excludeCheck] = false
-				} else {
-					return fmt.Errorf("unknown check to exclude: %s", excludeCheck)
-				}
+		var fileRules, projectRules []rules.Rule
+		for _, rule := range selectedRules {
+			if rules.IsProjectScoped(rule) {
+				projectRules = append(projectRules, rule)
+			} else {
+				fileRules = append(fileRules, rule)
 			}
 		}
 
-		// Initialize the strings file manager
-		manager, err := localizable.NewStringsFileManager([]string{checkOptions.stringsPath})
-		if err != nil {
-			return fmt.Errorf("error initializing strings manager: %w", err)
+		// Known issues of the baseline are not reported, unless the baseline is recreated
+		configString(cmd, "baseline", &checkOptions.baselinePath, projectConfig.BaselinePath())
+		if checkOptions.baselinePath == "" {
+			checkOptions.baselinePath = defaultBaselinePath()
 		}
+		var knownIssues *baseline.Baseline
+		if !checkOptions.updateBaseline {
+			knownIssues, err = loadBaseline(checkOptions.baselinePath)
+			if err != nil {
+				return err
+			}
+		}
+		printsDiagnostics := !isMachineReadable(checkOptions.format) && !checkOptions.updateBaseline
 
-		// Start a spinner to provide feedback while processing
-		s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
-		s.Start()
-
-		var unsortedFiles []*localizable.StringsFile
-		var filesWithDuplicates []*localizable.StringsFile
-		var filesWithEmptyValues []*localizable.StringsFile
+		root := checkRoot(checkOptions.stringsPaths)
+		var diagnostics []rules.Diagnostic
+		var ranRules []string
 
-		// Perform the checks based on the active checks map
-		for _, file := range manager.Files {
+		ctx := &rules.Context{
+			IgnorePatterns: checkOptions.ignorePatterns,
+			Root:           root,
+			UsagePatterns:  projectConfig.CompiledUsagePatterns(),
+			Config:         projectConfig,
+		}
 
-			// Check for sorting if enabled
-			if activeChecks[CheckSorting] && (!file.IsSorted() || !file.IsSanitized()) {
-				unsortedFiles = append(unsortedFiles, file)
+		if checkOptions.perTarget {
+			err := forEachTarget(root, checkOptions.ignorePatterns, func(target *internal.Target, manager *localizable.StringsFileManager, set *localizable.LocalizationSet) error {
+				targetCtx := &rules.Context{
+					Files:          manager.Files,
+					Set:            set,
+					SourcePaths:    target.SourcePaths,
+					IgnorePatterns: checkOptions.ignorePatterns,
+					Root:           root,
+					Target:         target,
+					UsagePatterns:  ctx.UsagePatterns,
+					Config:         projectConfig,
+				}
+				targetDiagnostics, err := rules.Run(targetCtx, fileRules)
+				if err != nil {
+					return err
+				}
+				targetDiagnostics = knownIssues.Filter(targetDiagnostics)
+				if printsDiagnostics {
+					printDiagnostics(targetDiagnostics)
+				}
+				diagnostics = append(diagnostics, targetDiagnostics...)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if printsDiagnostics {
+				fmt.Println()
 			}
 
-			// Check for duplicates if enabled
-			if activeChecks[CheckDuplicates] && file.HasDuplicates() {
-				filesWithDuplicates = append(filesWithDuplicates, file)
+			ranRules = ruleIDs(fileRules)
+			// only the project scoped rules are left
+			fileRules = nil
+		} else {
+			// Initialize the strings file manager
+			manager, err := localizable.NewStringsFileManager(checkOptions.stringsPaths)
+			if err != nil {
+				return fmt.Errorf("error initializing strings manager: %w", err)
 			}
 
-			// Check for empty values if enabled
-			if activeChecks[CheckEmptyValues] && file.HasEmptyValues() {
-				filesWithEmptyValues = append(filesWithEmptyValues, file)
+			// Resolve the base files for rules comparing with them
+			ctx.Set = manager.LocalizationSet("")
+			if requiresBase(fileRules) {
+				set, err := resolveLocalizationSet(manager, checkOptions.baseStringsPath)
+				if err != nil {
+					// only fail if such a rule was requested explicitly
+					if requiresBase(filterRules(fileRules, checkOptions.includeChecks)) {
+						return err
+					}
+					color.Yellow("Skipping checks which compare with the base files: %s", err)
+					fileRules = withoutBaseRules(fileRules)
+				} else {
+					ctx.Set = set
+				}
 			}
-		}
 
-		// Check for unused keys if enabled
-		var unusedKeys []string
-		if activeChecks[CheckUnused] {
-			keysForBaseStrings := manager.GetKeysForFile(checkOptions.baseStringsPath)
-			unusedKeys = internal.FindUnusedKeysInSwiftFiles(checkOptions.swiftDirectory, keysForBaseStrings, checkOptions.ignorePatterns)
+			ctx.Files = manager.Files
+			ctx.SourcePaths = checkOptions.swiftDirectories
 		}
 
-		// Stop the spinner after processing
+		// Start a spinner to provide feedback while processing
+		s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
+		s.Start()
+		ranRules = append(ranRules, ruleIDs(append(fileRules, projectRules...))...)
+		remainingDiagnostics, err := rules.Run(ctx, append(fileRules, projectRules...))
 		s.Stop()
-
-		// Display results for the various checks
-		if len(unsortedFiles) > 0 {
-			color.Yellow("Unsorted files (%d): ", len(unsortedFiles))
-			for _, file := range unsortedFiles {
-				fmt.Println(file.Path)
-			}
+		if err != nil {
+			return err
 		}
-
-		if len(filesWithDuplicates) > 0 {
-			color.Yellow("Files with duplicates (%d):", len(filesWithDuplicates))
-			for _, file := range filesWithDuplicates {
-				fmt.Println(file.Path)
+		remainingDiagnostics = knownIssues.Filter(remainingDiagnostics)
+		diagnostics = append(diagnostics, remainingDiagnostics...)
+
+		if checkOptions.updateBaseline {
+			// keep the known issues of the rules which did not run, e.g. because of --include or --exclude
+			previousIssues, err := loadBaseline(checkOptions.baselinePath)
+			if err != nil {
+				return err
 			}
-		}
-
-		if len(filesWithEmptyValues) > 0 {
-			color.Yellow("Files with empty values (%d):", len(filesWithEmptyValues))
-			for _, file := range filesWithEmptyValues {
-				fmt.Println(file.Path)
+			knownIssues = baseline.New(checkOptions.baselinePath, diagnostics)
+			knownIssues.Retain(previousIssues, ranRules)
+			if err := knownIssues.Save(); err != nil {
+				return fmt.Errorf("error writing baseline: %w", err)
 			}
+			color.Green("Wrote %d known issues (%d entries) to %s", len(diagnostics), len(knownIssues.Entries), checkOptions.baselinePath)
+			return nil
 		}
 
-		if len(unusedKeys) > 0 {
-			color.Yellow("Unused keys (%d):\n", len(unusedKeys))
-			for _, key := range unusedKeys {
-				fmt.Println(key)
+		if isMachineReadable(checkOptions.format) {
+			if err := writeReport(checkOptions.format, "check", diagnostics); err != nil {
+				return err
 			}
+		} else {
+			printDiagnostics(remainingDiagnostics)
 		}
 
+		printFixedBaselineEntries(knownIssues, ranRules)
+
 		// Determine if any issues were found and handle the exit status
-		anyIssuesOccurred := len(unsortedFiles) > 0 || len(filesWithDuplicates) > 0 || len(filesWithEmptyValues) > 0 || len(unusedKeys) > 0
-		if anyIssuesOccurred {
+		if hasIssues(diagnostics) {
 			color.Red("Issues found. 🚧")
 			if checkOptions.exitOnIssue {
 				os.Exit(1)
@@ -208,14 +254,175 @@ var checkCmd = &cobra.Command{
 	},
 }
 
+// checkRoot returns the directory in which .lproj directories and projects are searched for the given strings paths
+func checkRoot(stringsPaths []string) string {
+	if len(stringsPaths) != 1 {
+		return "."
+	}
+	if isDir, _ := internal.IsDirectory(stringsPaths[0]); isDir {
+		return stringsPaths[0]
+	}
+	return "."
+}
+
+// defaultBaselinePath returns the baseline file next to the configuration file or in the working directory
+func defaultBaselinePath() string {
+	if projectConfig != nil {
+		return projectConfig.ResolvePath(baseline.FileName)
+	}
+	return baseline.FileName
+}
+
+// loadBaseline loads the baseline file at path. It returns nil if the file does not exist.
+func loadBaseline(path string) (*baseline.Baseline, error) {
+	knownIssues, err := baseline.Load(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("Using baseline: %s\n", path)
+	return knownIssues, nil
+}
+
+// printFixedBaselineEntries lists known issues of the rules which ran that are not reported anymore
+func printFixedBaselineEntries(knownIssues *baseline.Baseline, ranRules []string) {
+	fixed := knownIssues.Fixed(ranRules)
+	if len(fixed) == 0 {
+		return
+	}
+
+	color.Green("Fixed issues of the baseline (%d), run `xcs check --update-baseline` to remove them:", len(fixed))
+	for _, entry := range fixed {
+		description := entry.Key
+		if description == "" {
+			description = entry.Message
+		}
+		fmt.Printf("%s: %s [%s]\n", entry.Path, description, entry.Rule)
+	}
+	fmt.Println()
+}
+
+func ruleIDs(selectedRules []rules.Rule) []string {
+	ids := make([]string, 0, len(selectedRules))
+	for _, rule := range selectedRules {
+		ids = append(ids, rule.ID())
+	}
+	return ids
+}
+
+func requiresBase(selectedRules []rules.Rule) bool {
+	for _, rule := range selectedRules {
+		if rules.RequiresBase(rule) {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutBaseRules(selectedRules []rules.Rule) []rules.Rule {
+	var result []rules.Rule
+	for _, rule := range selectedRules {
+		if !rules.RequiresBase(rule) {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
+
+// withoutDisabledRules removes the rules turned off in the project configuration unless they are included explicitly
+func withoutDisabledRules(selectedRules []rules.Rule, include []string) []rules.Rule {
+	var result []rules.Rule
+	for _, rule := range selectedRules {
+		if !projectConfig.IsRuleDisabled(rule.ID()) || contains(include, rule.ID()) {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
+
+func filterRules(selectedRules []rules.Rule, ids []string) []rules.Rule {
+	var result []rules.Rule
+	for _, rule := range selectedRules {
+		if contains(ids, rule.ID()) {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
+
+// hasIssues reports whether any diagnostic is more severe than info
+func hasIssues(diagnostics []rules.Diagnostic) bool {
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity != rules.SeverityInfo {
+			return true
+		}
+	}
+	return false
+}
+
+// printDiagnostics prints one line per diagnostic in the form `path:line:column: severity: message [rule]`,
+// followed by the number of diagnostics per rule
+func printDiagnostics(diagnostics []rules.Diagnostic) {
+	counts := make(map[string]int)
+	for _, diagnostic := range diagnostics {
+		fmt.Printf("%s: %s %s [%s]\n", diagnostic.Location(), severityLabel(diagnostic.Severity), diagnostic.Message, diagnostic.RuleID)
+		counts[diagnostic.RuleID]++
+	}
+
+	if len(diagnostics) == 0 {
+		return
+	}
+	fmt.Println()
+	for _, rule := range rules.All() {
+		if count := counts[rule.ID()]; count > 0 {
+			color.Yellow("%s (%d): %s", rule.ID(), count, rule.Description())
+		}
+	}
+}
+
+// severityLabel colors the severity like compilers do, so that Xcode and other IDEs recognize the line
+func severityLabel(severity rules.Severity) string {
+	switch severity {
+	case rules.SeverityError:
+		return color.RedString("error:")
+	case rules.SeverityInfo:
+		return color.CyanString("note:")
+	default:
+		return color.YellowString("warning:")
+	}
+}
+
+func printRules() {
+	width := 0
+	for _, rule := range rules.All() {
+		width = max(width, len(rule.ID()))
+	}
+	for _, rule := range rules.All() {
+		enabled := "default"
+		if rules.IsOptIn(rule) {
+			enabled = "opt-in"
+		}
+		fmt.Printf("%-*s %-8s %-8s %s\n", width, rule.ID(), rule.DefaultSeverity(), enabled, rule.Description())
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(checkCmd)
-	checkCmd.Flags().StringVarP(&checkOptions.baseStringsPath, "base", "b", "", "Path to the base Localizable.strings file which is used as reference for finding unused keys (required)")
-	checkCmd.Flags().StringVarP(&checkOptions.swiftDirectory, "swift-dir", "d", "", "Path to the directory containing Swift files (.)")
+	checkCmd.Flags().StringVarP(&checkOptions.baseStringsPath, "base", "b", "", "Path to the base Localizable.strings file which is used as reference for finding unused keys (detected from the Xcode project or Swift package if omitted)")
+	checkCmd.Flags().StringSliceVarP(&checkOptions.swiftDirectories, "swift-dir", "d", []string{"."}, "Paths to the directories containing Swift files")
 	checkCmd.Flags().StringSliceVarP(&checkOptions.ignorePatterns, "ignore", "i", constants.DefaultIgnorePatterns, "Glob patterns for files or directories to ignore")
+	checkCmd.Flags().BoolVar(&checkOptions.perTarget, "targets", false, "Check each Xcode or Swift package target separately")
+	checkCmd.Flags().BoolVar(&checkOptions.listRules, "list", false, "List all available checks")
+	addFormatFlag(checkCmd, &checkOptions.format)
+	checkCmd.Flags().StringVar(&checkOptions.baselinePath, "baseline", "", fmt.Sprintf("Path to the baseline file of known issues (default: %s next to the configuration file or in the working directory)", baseline.FileName))
+	checkCmd.Flags().BoolVar(&checkOptions.updateBaseline, "update-baseline", false, "Write all current issues to the baseline file instead of reporting them")
+	checkCmd.Flags().BoolVar(&checkOptions.fix, "fix", false, "Fix the issues of fixable rules before checking, like `xcs fix`")
+	checkCmd.Flags().BoolVar(&checkOptions.dryRun, "dry-run", false, "Prints the fixes of --fix as unified diff without writing them to the files")
 
 	// Flags for include and exclude lists
-	availableChecks := fmt.Sprintf("%s", allChecks)
+	availableChecks := fmt.Sprintf("%s", rules.IDs())
 	checkCmd.Flags().StringSliceVar(&checkOptions.includeChecks, "include", []string{}, fmt.Sprintf("List of checks to include (%s)", availableChecks))
 	checkCmd.Flags().StringSliceVar(&checkOptions.excludeChecks, "exclude", []string{}, fmt.Sprintf("List of checks to exclude (%s)", availableChecks))
 }
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/phillippbertram/xc-strings/internal"
+	"github.com/phillippbertram/xc-strings/internal/configfile"
+	"github.com/phillippbertram/xc-strings/internal/constants"
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+	"github.com/phillippbertram/xc-strings/internal/rules"
+)
+
+// projectConfig is the loaded .xcstrings.yml, nil if there is none
+var projectConfig *configfile.Config
+
+// configPath is the explicitly given path of the configuration file
+var configPath string
+
+type ConfigInitOptions struct {
+	force bool
+}
+
+var configInitOptions ConfigInitOptions
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the project configuration file (.xcstrings.yml)",
+	Long: heredoc.Doc(`
+		The project configuration file .xcstrings.yml is searched in the working directory and its parents.
+		It configures the base file, strings and source paths, ignore patterns, rules and usage patterns.
+		Flags given on the command line override the values of the configuration file.
+	`),
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create a .xcstrings.yml for the Xcode project or Swift package in the working directory",
+	Example: heredoc.Doc(`
+		xcs config init
+		xcs config init --force
+	`),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := os.Stat(configfile.FileName); err == nil && !configInitOptions.force {
+			return fmt.Errorf("%s already exists, use --force to overwrite it", configfile.FileName)
+		}
+
+		config, err := scaffoldConfig(".")
+		if err != nil {
+			return err
+		}
+
+		if err := config.Save(configfile.FileName); err != nil {
+			return fmt.Errorf("error writing %s: %w", configfile.FileName, err)
+		}
+
+		color.Green("Created %s", configfile.FileName)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configInitCmd)
+	configInitCmd.Flags().BoolVar(&configInitOptions.force, "force", false, "Overwrite an existing configuration file")
+
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", fmt.Sprintf("Path to the project configuration file (default: %s in the working directory or its parents)", configfile.FileName))
+	rootCmd.PersistentPreRunE = loadProjectConfig
+}
+
+// loadProjectConfig loads the configuration file before any command is run
+func loadProjectConfig(cmd *cobra.Command, args []string) error {
+	if err := prepareReportOutput(cmd); err != nil {
+		return err
+	}
+
+	var err error
+	if configPath != "" {
+		projectConfig, err = configfile.Load(configPath)
+	} else {
+		projectConfig, err = configfile.FindAndLoad(".")
+	}
+	if err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	if projectConfig != nil {
+		fmt.Printf("Using config file: %s\n", projectConfig.Path)
+	}
+	return nil
+}
+
+// configString sets value to the configured value if the flag was not given on the command line
+func configString(cmd *cobra.Command, flag string, value *string, configValue string) {
+	if !cmd.Flags().Changed(flag) && configValue != "" {
+		*value = configValue
+	}
+}
+
+// configStrings sets values to the configured values if the flag was not given on the command line
+func configStrings(cmd *cobra.Command, flag string, values *[]string, configValues []string) {
+	if !cmd.Flags().Changed(flag) && len(configValues) > 0 {
+		*values = configValues
+	}
+}
+
+// stringsPathsFromArgs returns the strings paths given as arguments, the configured ones or the default paths
+func stringsPathsFromArgs(args []string, defaultPaths []string) []string {
+	if len(args) > 0 {
+		return args
+	}
+	if paths := projectConfig.StringsPaths(); len(paths) > 0 {
+		return paths
+	}
+	return defaultPaths
+}
+
+// requireStringsPaths is like stringsPathsFromArgs for commands without default paths
+func requireStringsPaths(args []string) ([]string, error) {
+	paths := stringsPathsFromArgs(args, nil)
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no strings path given and none configured in %s", configfile.FileName)
+	}
+	return paths, nil
+}
+
+// scaffoldConfig creates a configuration for the Xcode project or Swift package in dir
+func scaffoldConfig(dir string) (*configfile.Config, error) {
+	project, err := internal.FindProject(dir)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("Found %s with base language '%s'\n", project.Path, project.DevelopmentRegion)
+
+	targets, err := internal.FindTargets(dir, constants.DefaultIgnorePatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	stringsDirs := map[string]struct{}{}
+	sourceDirs := map[string]struct{}{}
+	var stringsFiles []string
+	for _, target := range targets {
+		for _, file := range target.StringsFiles {
+			// the directory containing the .lproj directories
+			stringsDirs[filepath.Dir(filepath.Dir(file))] = struct{}{}
+			stringsFiles = append(stringsFiles, file)
+		}
+		for _, path := range target.SourcePaths {
+			if isDir, _ := internal.IsDirectory(path); !isDir {
+				path = filepath.Dir(path)
+			}
+			sourceDirs[path] = struct{}{}
+		}
+	}
+
+	config := &configfile.Config{
+		Strings: outermostPaths(stringsDirs),
+		Sources: outermostPaths(sourceDirs),
+		Ignore:  constants.DefaultIgnorePatterns,
+		Rules:   map[string]configfile.RuleConfig{},
+	}
+
+	// a single Localizable.strings of the base language can be configured as base file
+	var baseFiles []string
+	for _, file := range localizable.NewLocalizationSet(parseFiles(stringsFiles), project.DevelopmentRegion).BaseFiles() {
+		if file.Table() == "Localizable" {
+			baseFiles = append(baseFiles, file.Path)
+		}
+	}
+	if len(baseFiles) == 1 {
+		config.Base = baseFiles[0]
+	}
+
+	// opt-in rules are listed turned off, so that they can be enabled by setting a severity
+	for _, rule := range rules.All() {
+		severity := string(rule.DefaultSeverity())
+		if rules.IsOptIn(rule) {
+			severity = configfile.SeverityOff
+		}
+		config.Rules[rule.ID()] = configfile.RuleConfig{Severity: severity}
+	}
+
+	return config, nil
+}
+
+// parseFiles parses the given strings files and skips files which cannot be read
+func parseFiles(paths []string) []*localizable.StringsFile {
+	var files []*localizable.StringsFile
+	for _, path := range paths {
+		if file, err := localizable.NewStringsFile(path); err == nil {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// outermostPaths returns the sorted paths which are not located inside of another path of the set
+func outermostPaths(paths map[string]struct{}) []string {
+	sorted := internal.MapToSlice(paths)
+	sort.Strings(sorted)
+
+	var result []string
+	for _, path := range sorted {
+		nested := false
+		for _, parent := range result {
+			if parent == "." || strings.HasPrefix(path, parent+string(filepath.Separator)) {
+				nested = true
+				break
+			}
+		}
+		if !nested {
+			result = append(result, path)
+		}
+	}
+	return result
+}
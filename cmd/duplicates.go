@@ -5,6 +5,7 @@ import (
 
 	"github.com/phillippbertram/xc-strings/internal/constants"
 	"github.com/phillippbertram/xc-strings/internal/localizable"
+	"github.com/phillippbertram/xc-strings/internal/rules"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/fatih/color"
@@ -17,7 +18,9 @@ import (
 type DuplicatesOptions struct {
 	paths            []string
 	removeDuplicates bool
+	near             bool
 	dryRun           bool
+	format           string
 }
 
 var duplicatesOptions DuplicatesOptions = DuplicatesOptions{
@@ -37,17 +40,30 @@ var duplicatesCmd = &cobra.Command{
 
 		# remove all but the last occurrence of each duplicate key
 		duplicates --remove
+
+		# find keys which differ only by case, whitespace, Unicode normalization or look-alike characters
+		duplicates --near
 	`),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if len(args) != 0 {
-			sortOptions.paths = args
-		}
+		duplicatesOptions.paths = stringsPathsFromArgs(args, duplicatesOptions.paths)
 
-		manager, err := localizable.NewStringsFileManager(sortOptions.paths)
+		manager, err := localizable.NewStringsFileManager(duplicatesOptions.paths)
 		if err != nil {
 			return fmt.Errorf("error initializing strings manager: %w", err)
 		}
 
+		if duplicatesOptions.near {
+			return findNearDuplicates(manager, duplicatesOptions.format)
+		}
+
+		if isMachineReadable(duplicatesOptions.format) {
+			diagnostics, err := runRule("duplicates", &rules.Context{Files: manager.Files, Config: projectConfig})
+			if err != nil {
+				return err
+			}
+			return writeReport(duplicatesOptions.format, "duplicates", diagnostics)
+		}
+
 		duplicates := manager.FindDuplicates()
 		if len(duplicates) == 0 {
 			color.Green("No duplicate keys found.")
@@ -90,4 +106,26 @@ func init() {
 	rootCmd.AddCommand(duplicatesCmd)
 	duplicatesCmd.Flags().BoolVar(&duplicatesOptions.removeDuplicates, "remove", false, "Remove all but the last occurrence of each duplicate key")
 	duplicatesCmd.Flags().BoolVar(&duplicatesOptions.dryRun, "dry-run", false, "Prints the changes without writing them to the file")
+	duplicatesCmd.Flags().BoolVar(&duplicatesOptions.near, "near", false, "Find keys which differ only by case, whitespace, Unicode normalization or look-alike characters, also across the tables of a bundle")
+	addFormatFlag(duplicatesCmd, &duplicatesOptions.format)
+}
+
+func findNearDuplicates(manager *localizable.StringsFileManager, format string) error {
+	diagnostics, err := runRule("nearDuplicates", &rules.Context{Files: manager.Files, Set: manager.LocalizationSet(""), Config: projectConfig})
+	if err != nil {
+		return err
+	}
+	if isMachineReadable(format) {
+		return writeReport(format, "duplicates", diagnostics)
+	}
+
+	if len(diagnostics) == 0 {
+		color.Green("No near-duplicate keys found.")
+		return nil
+	}
+	for _, diagnostic := range diagnostics {
+		fmt.Printf("%s: %s\n", diagnostic.Location(), diagnostic.Message)
+	}
+	color.Red("\nFound %d near-duplicate keys\n", len(diagnostics))
+	return nil
 }
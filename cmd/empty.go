@@ -5,6 +5,7 @@ import (
 
 	"github.com/phillippbertram/xc-strings/internal/constants"
 	"github.com/phillippbertram/xc-strings/internal/localizable"
+	"github.com/phillippbertram/xc-strings/internal/rules"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -13,11 +14,12 @@ import (
 // command to find empty translation values
 
 type EmptyOptions struct {
-	path string
+	paths  []string
+	format string
 }
 
 var emptyOptions EmptyOptions = EmptyOptions{
-	path: constants.DefaultStringsGlob,
+	paths: []string{constants.DefaultStringsGlob},
 }
 
 var emptyCmd = &cobra.Command{
@@ -25,13 +27,21 @@ var emptyCmd = &cobra.Command{
 	Short: "Find empty translation values in .strings files",
 	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		emptyOptions.path = args[0]
+		emptyOptions.paths = stringsPathsFromArgs(args, emptyOptions.paths)
 
-		manager, err := localizable.NewStringsFileManager([]string{emptyOptions.path})
+		manager, err := localizable.NewStringsFileManager(emptyOptions.paths)
 		if err != nil {
 			return fmt.Errorf("error initializing strings manager: %w", err)
 		}
 
+		if isMachineReadable(emptyOptions.format) {
+			diagnostics, err := runRule("emptyValues", &rules.Context{Files: manager.Files, Config: projectConfig})
+			if err != nil {
+				return err
+			}
+			return writeReport(emptyOptions.format, "empty", diagnostics)
+		}
+
 		for idx, file := range manager.Files {
 			fmt.Printf("Checking %s\n", file.Path)
 			emptyLines := file.EmptyValues()
@@ -51,4 +61,5 @@ var emptyCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(emptyCmd)
+	addFormatFlag(emptyCmd, &emptyOptions.format)
 }
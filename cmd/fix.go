@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/fatih/color"
+	"github.com/phillippbertram/xc-strings/internal/constants"
+	"github.com/phillippbertram/xc-strings/internal/diff"
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+	"github.com/phillippbertram/xc-strings/internal/rules"
+	"github.com/spf13/cobra"
+)
+
+type FixOptions struct {
+	stringsPaths []string
+	includeRules []string
+	excludeRules []string
+	dryRun       bool
+}
+
+var fixOptions FixOptions
+
+var fixCmd = &cobra.Command{
+	Use:   "fix [strings-path]",
+	Short: "Fix issues of .strings files automatically",
+	Long: heredoc.Doc(`
+		Fixes the issues of all rules which can be corrected automatically, like unsorted keys,
+		duplicate keys sharing the same value, ... instead of … and superfluous whitespace.
+		Suppression comments and rules turned off in the configuration are respected.
+	`),
+	Example: heredoc.Doc(`
+		# fix all fixable issues
+		xcs fix App/Resources
+
+		# show the changes as unified diff without writing them
+		xcs fix App/Resources --dry-run
+
+		# only fix the given rules
+		xcs fix App/Resources --include sorting,ellipsis
+	`),
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fixOptions.stringsPaths = stringsPathsFromArgs(args, []string{constants.DefaultStringsGlob})
+
+		selectedRules, err := fixableRules(fixOptions.includeRules, fixOptions.excludeRules)
+		if err != nil {
+			return err
+		}
+		return applyFixes(fixOptions.stringsPaths, selectedRules, fixOptions.dryRun)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(fixCmd)
+	fixCmd.Flags().StringSliceVar(&fixOptions.includeRules, "include", []string{}, fmt.Sprintf("List of rules to fix (%s)", fixableRuleIDs()))
+	fixCmd.Flags().StringSliceVar(&fixOptions.excludeRules, "exclude", []string{}, "List of rules not to fix")
+	fixCmd.Flags().BoolVar(&fixOptions.dryRun, "dry-run", false, "Prints the changes as unified diff without writing them to the files")
+}
+
+// fixableRules selects the fixable rules with `--include` and `--exclude`. Rules turned off in the
+// project configuration are only fixed if they are included explicitly.
+func fixableRules(include []string, exclude []string) ([]rules.Rule, error) {
+	selectedRules, err := rules.Select(include, exclude, projectConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixable []rules.Rule
+	for _, rule := range withoutDisabledRules(selectedRules, include) {
+		if rules.IsFixable(rule) {
+			fixable = append(fixable, rule)
+		} else if contains(include, rule.ID()) {
+			return nil, fmt.Errorf("rule %s cannot be fixed automatically (fixable: %s)", rule.ID(), fixableRuleIDs())
+		}
+	}
+	return fixable, nil
+}
+
+func fixableRuleIDs() string {
+	var ids []string
+	for _, rule := range rules.All() {
+		if rules.IsFixable(rule) {
+			ids = append(ids, rule.ID())
+		}
+	}
+	return fmt.Sprint(ids)
+}
+
+// applyFixes fixes the strings files with the given rules. A dry-run prints the changes as unified diff instead of saving them.
+func applyFixes(stringsPaths []string, selectedRules []rules.Rule, dryRun bool) error {
+	manager, err := localizable.NewStringsFileManager(stringsPaths)
+	if err != nil {
+		return fmt.Errorf("error initializing strings manager: %w", err)
+	}
+
+	ctx := &rules.Context{
+		Files:  manager.Files,
+		Set:    manager.LocalizationSet(""),
+		Config: projectConfig,
+	}
+	results, err := rules.Fix(ctx, selectedRules)
+	if err != nil {
+		return err
+	}
+
+	total := 0
+	for _, result := range results {
+		total += result.Count()
+		if dryRun {
+			fmt.Print(diff.Unified("a/"+result.File.Path, "b/"+result.File.Path, result.Original, result.File.Content()))
+			continue
+		}
+
+		if err := result.File.Save(); err != nil {
+			return fmt.Errorf("error saving %s: %w", result.File.Path, err)
+		}
+		fmt.Printf("Fixed %s in %s\n", result.Describe(), result.File.Path)
+	}
+
+	switch {
+	case len(results) == 0:
+		color.Green("Nothing to fix. 🚀")
+	case dryRun:
+		color.Yellow("Dry-run completed. %d issues in %d files would be fixed, no changes were made.", total, len(results))
+	default:
+		color.Green("Fixed %d issues in %d files.", total, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/phillippbertram/xc-strings/internal"
+	"github.com/phillippbertram/xc-strings/internal/constants"
+	"github.com/phillippbertram/xc-strings/internal/rules"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+type LanguagesOptions struct {
+	path           string
+	ignorePatterns []string
+}
+
+var languagesOptions LanguagesOptions = LanguagesOptions{
+	path: ".",
+}
+
+var languagesCmd = &cobra.Command{
+	Use:   "languages [path]",
+	Short: "Compare the declared languages with the .lproj directories on disk",
+	Long: heredoc.Doc(`
+		Compares the languages declared in the Xcode project (knownRegions, developmentRegion)
+		or in String Catalogs with the .lproj directories found in the given path.
+		Reports declared languages without files, directories of undeclared languages
+		and tables missing from a language directory.
+	`),
+	Example: heredoc.Doc(`
+		# compare the languages of the Xcode project with all .lproj directories in the current directory
+		xcs languages
+
+		# only look for .lproj directories in a specific directory
+		xcs languages App/Resources
+	`),
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			languagesOptions.path = args[0]
+		}
+		configStrings(cmd, "ignore", &languagesOptions.ignorePatterns, projectConfig.IgnorePatterns())
+
+		report, err := rules.CheckLanguages(languagesOptions.path, languagesOptions.ignorePatterns)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Declared languages (%s): %s\n", strings.Join(report.Declared.Sources, ", "), strings.Join(report.Declared.Languages, ", "))
+		for _, directory := range report.Directories {
+			fmt.Printf("%s: %s\n", directory.Path, strings.Join(directory.Tables, ", "))
+		}
+		fmt.Println()
+
+		if !report.HasIssues() {
+			color.Green("All declared languages are consistent with the files on disk. 🚀")
+			return nil
+		}
+
+		printLanguageReport(report)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(languagesCmd)
+	languagesCmd.Flags().StringSliceVarP(&languagesOptions.ignorePatterns, "ignore", "i", constants.DefaultIgnorePatterns, "Glob patterns for files or directories to ignore")
+}
+
+func printLanguageReport(report *internal.LanguageReport) {
+	if len(report.DeclaredWithoutFiles) > 0 {
+		color.Yellow("Declared languages without files (%d):", len(report.DeclaredWithoutFiles))
+		for _, language := range report.DeclaredWithoutFiles {
+			fmt.Println(language)
+		}
+	}
+
+	if len(report.Undeclared) > 0 {
+		color.Yellow("Directories of undeclared languages (%d):", len(report.Undeclared))
+		for _, directory := range report.Undeclared {
+			fmt.Println(directory.Path)
+		}
+	}
+
+	if len(report.MissingTables) > 0 {
+		color.Yellow("Directories with missing tables (%d):", len(report.MissingTables))
+		for _, path := range sortedKeys(report.MissingTables) {
+			fmt.Printf("%s: %s\n", path, strings.Join(report.MissingTables[path], ", "))
+		}
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -5,81 +5,153 @@ import (
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/fatih/color"
+	"github.com/phillippbertram/xc-strings/internal"
+	"github.com/phillippbertram/xc-strings/internal/constants"
 	"github.com/phillippbertram/xc-strings/internal/localizable"
+	"github.com/phillippbertram/xc-strings/internal/rules"
 	"github.com/spf13/cobra"
 )
 
 type MissingCmdOptions struct {
 	baseStringsPath string
-	stringsPath     string
+	stringsPaths    []string
+	ignorePatterns  []string
+	perTarget       bool
+	format          string
 }
 
-var missingOptions MissingCmdOptions = MissingCmdOptions{}
+var missingOptions MissingCmdOptions = MissingCmdOptions{
+	ignorePatterns: constants.DefaultIgnorePatterns,
+}
 
 var missingCmd = &cobra.Command{
-	Use:   "missing [strings-path] -b <base Localizable.strings>",
+	Use:   "missing [strings-path] [-b <base Localizable.strings>]",
 	Short: "Find missing translations in the strings files",
 	Example: heredoc.Doc(`
 		# find missing translations in all .strings files in the current directory and its subdirectories
 		xcs missing App/Resources -b App/Resources/en.lproj/Localizable.strings
+
+		# use the development region of the Xcode project or Swift package to find the base file of each table
+		xcs missing App/Resources
+
+		# find missing translations separately for each Xcode or Swift package target
+		xcs missing Packages --targets
 	`),
-	Args: cobra.ExactArgs(1),
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		missingOptions.stringsPath = args[0]
+		stringsPaths, err := requireStringsPaths(args)
+		if err != nil {
+			return err
+		}
+		missingOptions.stringsPaths = stringsPaths
+		configString(cmd, "base", &missingOptions.baseStringsPath, projectConfig.BasePath())
+		configStrings(cmd, "ignore", &missingOptions.ignorePatterns, projectConfig.IgnorePatterns())
 		return findMissingKeys(missingOptions)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(missingCmd)
-	missingCmd.Flags().StringVarP(&missingOptions.baseStringsPath, "base", "b", "", "Path to the base Localizable.strings file which is used as reference for finding unused keys (required)")
+	missingCmd.Flags().StringVarP(&missingOptions.baseStringsPath, "base", "b", "", "Path to the base Localizable.strings file which is used as reference for finding missing translations (detected from the Xcode project or Swift package if omitted)")
+	missingCmd.Flags().StringSliceVarP(&missingOptions.ignorePatterns, "ignore", "i", constants.DefaultIgnorePatterns, "Glob patterns for files or directories to ignore when analyzing targets")
+	missingCmd.Flags().BoolVar(&missingOptions.perTarget, "targets", false, "Analyze each Xcode or Swift package target separately")
+	addFormatFlag(missingCmd, &missingOptions.format)
 }
 
 func findMissingKeys(opts MissingCmdOptions) error {
-	manager, err := localizable.NewStringsFileManager([]string{missingOptions.stringsPath})
+	if opts.perTarget {
+		var diagnostics []rules.Diagnostic
+		err := forEachTarget(checkRoot(opts.stringsPaths), opts.ignorePatterns, func(target *internal.Target, manager *localizable.StringsFileManager, set *localizable.LocalizationSet) error {
+			missingTranslations := findMissingTranslations(set)
+			if isMachineReadable(opts.format) {
+				diagnostics = append(diagnostics, missingTranslationDiagnostics(missingTranslations)...)
+			} else {
+				printMissingTranslations(missingTranslations)
+			}
+			return nil
+		})
+		if err != nil || !isMachineReadable(opts.format) {
+			return err
+		}
+		return writeReport(opts.format, "missing", diagnostics)
+	}
+
+	manager, err := localizable.NewStringsFileManager(opts.stringsPaths)
 	if err != nil {
 		return fmt.Errorf("error initializing strings manager: %w", err)
 	}
 
-	baseFile := manager.GetFile(opts.baseStringsPath)
-	baseKeys := manager.GetKeysForFile(opts.baseStringsPath)
+	set, err := resolveLocalizationSet(manager, opts.baseStringsPath)
+	if err != nil {
+		return err
+	}
+
+	missingTranslations := findMissingTranslations(set)
+	if isMachineReadable(opts.format) {
+		return writeReport(opts.format, "missing", missingTranslationDiagnostics(missingTranslations))
+	}
+
+	printMissingTranslations(missingTranslations)
+	return nil
+}
+
+// findMissingTranslations returns the base lines of all keys missing in each translation of a table
+func findMissingTranslations(set *localizable.LocalizationSet) map[string][]localizable.Line {
 	var missingTranslations map[string][]localizable.Line = make(map[string][]localizable.Line)
 
-	for _, file := range manager.Files {
-		// Skip the base file
-		if file.Path == opts.baseStringsPath {
+	for _, table := range set.Tables {
+		// Skip tables without a base file
+		base := table.Base()
+		if base == nil {
 			continue
 		}
-		// fmt.Printf("Checking %s:", file.Path)
 
-		keys := manager.GetKeysForFile(file.Path)
-		// fmt.Printf(" %d Keys\n", len(keys))
-
-		for _, key := range baseKeys {
-			if !contains(keys, key) {
-				if missingTranslations[file.Path] == nil {
-					missingTranslations[file.Path] = make([]localizable.Line, 0)
+		keys := base.Keys()
+		baseEntries := base.Entries()
+		for _, translation := range table.Translations() {
+			entries := translation.Entries()
+			for _, key := range keys {
+				if _, ok := entries[key]; !ok {
+					missingTranslations[translation.File.Path] = append(missingTranslations[translation.File.Path], *baseEntries[key])
 				}
-				line := baseFile.GetLinesForKey(key)[0]
-				missingTranslations[file.Path] = append(missingTranslations[file.Path], line)
 			}
 		}
 	}
 
+	return missingTranslations
+}
+
+// missingTranslationDiagnostics converts the missing translations into diagnostics of the translation files
+func missingTranslationDiagnostics(missingTranslations map[string][]localizable.Line) []rules.Diagnostic {
+	var diagnostics []rules.Diagnostic
+	for _, file := range sortedKeys(missingTranslations) {
+		for _, line := range missingTranslations[file] {
+			diagnostics = append(diagnostics, rules.Diagnostic{
+				RuleID:   "missing",
+				Severity: rules.SeverityWarning,
+				Path:     file,
+				Key:      line.Key,
+				Message:  fmt.Sprintf("missing translation for key `%s`", line.Key),
+			})
+		}
+	}
+	return diagnostics
+}
+
+func printMissingTranslations(missingTranslations map[string][]localizable.Line) {
 	if len(missingTranslations) == 0 {
 		color.Green("No missing translations found.\n")
-		return nil
+		return
 	}
 
-	for file, lines := range missingTranslations {
+	for _, file := range sortedKeys(missingTranslations) {
+		lines := missingTranslations[file]
 		color.Yellow("%d Missing translations in %s:\n", len(lines), file)
 		for _, key := range lines {
 			fmt.Println(key.Text)
 		}
 		fmt.Println()
 	}
-
-	return nil
 }
 
 func contains(keys []string, key string) bool {
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/fatih/color"
+	"github.com/phillippbertram/xc-strings/internal"
+	"github.com/phillippbertram/xc-strings/internal/constants"
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+	"github.com/phillippbertram/xc-strings/internal/rules"
+	"github.com/spf13/cobra"
+)
+
+type OrphansOptions struct {
+	baseStringsPath string
+	stringsPaths    []string
+	ignorePatterns  []string
+	perTarget       bool
+	remove          bool
+	dryRun          bool
+	format          string
+}
+
+var orphansOptions OrphansOptions = OrphansOptions{
+	ignorePatterns: constants.DefaultIgnorePatterns,
+}
+
+var orphansCmd = &cobra.Command{
+	Use:   "orphans [strings-path] [-b <base Localizable.strings>]",
+	Short: "Find keys in translations which are not defined in the base file",
+	Long: heredoc.Doc(`
+		Finds keys which exist in a translation but not in the base file of the table.
+		They are usually left behind after a base key was renamed or deleted.
+	`),
+	Example: heredoc.Doc(`
+		# find orphan keys in all translations of App/Resources
+		xcs orphans App/Resources -b App/Resources/en.lproj/Localizable.strings
+
+		# show which keys would be removed, then remove them
+		xcs orphans App/Resources --remove --dry-run
+		xcs orphans App/Resources --remove
+	`),
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stringsPaths, err := requireStringsPaths(args)
+		if err != nil {
+			return err
+		}
+		orphansOptions.stringsPaths = stringsPaths
+		configString(cmd, "base", &orphansOptions.baseStringsPath, projectConfig.BasePath())
+		configStrings(cmd, "ignore", &orphansOptions.ignorePatterns, projectConfig.IgnorePatterns())
+		return findOrphans(orphansOptions)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(orphansCmd)
+	orphansCmd.Flags().StringVarP(&orphansOptions.baseStringsPath, "base", "b", "", "Path to the base Localizable.strings file (detected from the Xcode project or Swift package if omitted)")
+	orphansCmd.Flags().StringSliceVarP(&orphansOptions.ignorePatterns, "ignore", "i", constants.DefaultIgnorePatterns, "Glob patterns for files or directories to ignore when analyzing targets")
+	orphansCmd.Flags().BoolVar(&orphansOptions.perTarget, "targets", false, "Analyze each Xcode or Swift package target separately")
+	orphansCmd.Flags().BoolVar(&orphansOptions.remove, "remove", false, "Remove the orphan keys from the translations")
+	orphansCmd.Flags().BoolVar(&orphansOptions.dryRun, "dry-run", false, "Prints the keys which would be removed without changing the files")
+	addFormatFlag(orphansCmd, &orphansOptions.format)
+}
+
+func findOrphans(opts OrphansOptions) error {
+	var diagnostics []rules.Diagnostic
+	handleSet := func(set *localizable.LocalizationSet) error {
+		ctx := &rules.Context{Set: set, Config: projectConfig}
+		orphans, err := runRule("orphans", ctx)
+		if err != nil {
+			return err
+		}
+
+		if isMachineReadable(opts.format) {
+			diagnostics = append(diagnostics, orphans...)
+		} else {
+			printOrphans(orphans)
+		}
+
+		if opts.remove {
+			return removeOrphans(set, orphans, opts.dryRun)
+		}
+		return nil
+	}
+
+	if opts.perTarget {
+		err := forEachTarget(checkRoot(opts.stringsPaths), opts.ignorePatterns, func(target *internal.Target, manager *localizable.StringsFileManager, set *localizable.LocalizationSet) error {
+			return handleSet(set)
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		manager, err := localizable.NewStringsFileManager(opts.stringsPaths)
+		if err != nil {
+			return fmt.Errorf("error initializing strings manager: %w", err)
+		}
+
+		set, err := resolveLocalizationSet(manager, opts.baseStringsPath)
+		if err != nil {
+			return err
+		}
+
+		if err := handleSet(set); err != nil {
+			return err
+		}
+	}
+
+	if isMachineReadable(opts.format) {
+		return writeReport(opts.format, "orphans", diagnostics)
+	}
+	return nil
+}
+
+func printOrphans(orphans []rules.Diagnostic) {
+	if len(orphans) == 0 {
+		color.Green("No orphan keys found. 🚀")
+		return
+	}
+
+	for _, orphan := range orphans {
+		fmt.Printf("%s: %s\n", orphan.Location(), orphan.Key)
+	}
+	color.Red("\nFound %d orphan keys\n", len(orphans))
+}
+
+// removeOrphans removes the reported orphan keys from the translations of the set.
+// Keys suppressed by xcs-disable comments are not reported, so they are kept.
+func removeOrphans(set *localizable.LocalizationSet, orphans []rules.Diagnostic, dryRun bool) error {
+	keysByPath := make(map[string][]string)
+	for _, orphan := range orphans {
+		path := filepath.Clean(orphan.Path)
+		keysByPath[path] = append(keysByPath[path], orphan.Key)
+	}
+
+	for _, table := range set.Tables {
+		for _, translation := range table.Translations() {
+			keys := keysByPath[filepath.Clean(translation.File.Path)]
+			if len(keys) == 0 {
+				continue
+			}
+
+			if dryRun {
+				fmt.Printf("Would remove %d orphan keys from %s\n", len(keys), translation.File.Path)
+				continue
+			}
+
+			for _, key := range keys {
+				translation.File.RemoveKey(key)
+			}
+			if err := translation.File.Save(); err != nil {
+				return fmt.Errorf("error saving file: %w", err)
+			}
+			fmt.Printf("Removed %d orphan keys from %s\n", len(keys), translation.File.Path)
+		}
+	}
+
+	if dryRun {
+		color.Yellow("Dry-run completed. No changes were made.")
+	} else {
+		color.Green("All orphan keys removed successfully.")
+	}
+	return nil
+}
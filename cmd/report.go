@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/phillippbertram/xc-strings/internal/report"
+	"github.com/phillippbertram/xc-strings/internal/rules"
+)
+
+// reportOutput receives the machine-readable report. All other output goes to stderr while a report is written.
+var reportOutput io.Writer = os.Stdout
+
+// addFormatFlag adds the --format flag to a command whose findings can be written as report
+func addFormatFlag(cmd *cobra.Command, format *string) {
+	cmd.Flags().StringVar(format, "format", string(report.FormatText), fmt.Sprintf("Output format (%s)", strings.Join(report.Formats(), ", ")))
+}
+
+// prepareReportOutput redirects the informational output to stderr if the command writes a machine-readable report,
+// so that stdout only contains the report
+func prepareReportOutput(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("format")
+	if flag == nil {
+		return nil
+	}
+
+	format, err := report.ParseFormat(flag.Value.String())
+	if err != nil {
+		return err
+	}
+	if format.IsMachineReadable() {
+		reportOutput = os.Stdout
+		os.Stdout = os.Stderr
+		color.Output = color.Error
+	}
+	return nil
+}
+
+// writeReport writes the findings of a command in a machine-readable format
+func writeReport(format string, command string, diagnostics []rules.Diagnostic) error {
+	reportFormat, err := report.ParseFormat(format)
+	if err != nil {
+		return err
+	}
+	return report.Write(reportOutput, reportFormat, command, diagnostics)
+}
+
+// isMachineReadable reports whether the value of a --format flag is a machine-readable format
+func isMachineReadable(format string) bool {
+	return report.Format(format).IsMachineReadable()
+}
+
+// runRule runs a single registered rule, for commands which report the findings of a rule
+func runRule(id string, ctx *rules.Context) ([]rules.Diagnostic, error) {
+	rule, ok := rules.Get(id)
+	if !ok {
+		return nil, fmt.Errorf("unknown rule: %s", id)
+	}
+	return rules.Run(ctx, []rules.Rule{rule})
+}
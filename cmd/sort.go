@@ -41,9 +41,7 @@ var sortCmd = &cobra.Command{
 	`),
 	RunE: func(cmd *cobra.Command, args []string) error {
 
-		if len(args) != 0 {
-			sortOptions.paths = args
-		}
+		sortOptions.paths = stringsPathsFromArgs(args, sortOptions.paths)
 
 		manager, err := localizable.NewStringsFileManager(sortOptions.paths)
 		if err != nil {
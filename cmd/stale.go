@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/fatih/color"
+	"github.com/phillippbertram/xc-strings/internal"
+	"github.com/phillippbertram/xc-strings/internal/constants"
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+	"github.com/phillippbertram/xc-strings/internal/rules"
+	"github.com/spf13/cobra"
+)
+
+type StaleOptions struct {
+	baseStringsPath string
+	stringsPaths    []string
+	ignorePatterns  []string
+	perTarget       bool
+	format          string
+}
+
+var staleOptions StaleOptions = StaleOptions{
+	ignorePatterns: constants.DefaultIgnorePatterns,
+}
+
+var staleCmd = &cobra.Command{
+	Use:   "stale [strings-path] [-b <base Localizable.strings>]",
+	Short: "Find translations which are outdated because their base value changed",
+	Long: heredoc.Doc(`
+		Finds translations whose base value was modified after the translation was last changed.
+		The local git history of the strings files is used, files which are not committed are skipped.
+		The old and the new base value are reported, so that translators know what changed.
+	`),
+	Example: heredoc.Doc(`
+		# find stale translations in App/Resources
+		xcs stale App/Resources -b App/Resources/en.lproj/Localizable.strings
+
+		# hand the stale translations over as JSON
+		xcs stale App/Resources --format json > stale.json
+	`),
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stringsPaths, err := requireStringsPaths(args)
+		if err != nil {
+			return err
+		}
+		staleOptions.stringsPaths = stringsPaths
+		configString(cmd, "base", &staleOptions.baseStringsPath, projectConfig.BasePath())
+		configStrings(cmd, "ignore", &staleOptions.ignorePatterns, projectConfig.IgnorePatterns())
+		return findStale(staleOptions)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(staleCmd)
+	staleCmd.Flags().StringVarP(&staleOptions.baseStringsPath, "base", "b", "", "Path to the base Localizable.strings file (detected from the Xcode project or Swift package if omitted)")
+	staleCmd.Flags().StringSliceVarP(&staleOptions.ignorePatterns, "ignore", "i", constants.DefaultIgnorePatterns, "Glob patterns for files or directories to ignore when analyzing targets")
+	staleCmd.Flags().BoolVar(&staleOptions.perTarget, "targets", false, "Analyze each Xcode or Swift package target separately")
+	addFormatFlag(staleCmd, &staleOptions.format)
+}
+
+func findStale(opts StaleOptions) error {
+	var diagnostics []rules.Diagnostic
+	handleSet := func(set *localizable.LocalizationSet) error {
+		ctx := &rules.Context{Set: set, Config: projectConfig}
+		stale, err := runRule("stale", ctx)
+		if err != nil {
+			return err
+		}
+		diagnostics = append(diagnostics, stale...)
+		return nil
+	}
+
+	if opts.perTarget {
+		err := forEachTarget(checkRoot(opts.stringsPaths), opts.ignorePatterns, func(target *internal.Target, manager *localizable.StringsFileManager, set *localizable.LocalizationSet) error {
+			return handleSet(set)
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		manager, err := localizable.NewStringsFileManager(opts.stringsPaths)
+		if err != nil {
+			return fmt.Errorf("error initializing strings manager: %w", err)
+		}
+
+		set, err := resolveLocalizationSet(manager, opts.baseStringsPath)
+		if err != nil {
+			return err
+		}
+
+		if err := handleSet(set); err != nil {
+			return err
+		}
+	}
+
+	if isMachineReadable(opts.format) {
+		return writeReport(opts.format, "stale", diagnostics)
+	}
+	printStale(diagnostics)
+	return nil
+}
+
+func printStale(stale []rules.Diagnostic) {
+	if len(stale) == 0 {
+		color.Green("No stale translations found. 🚀")
+		return
+	}
+
+	for _, diagnostic := range stale {
+		fmt.Printf("%s: %s\n", diagnostic.Location(), diagnostic.Message)
+	}
+	color.Yellow("\nFound %d stale translations\n", len(stale))
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+
+	"github.com/phillippbertram/xc-strings/internal"
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+// targetFunc is called for each target with the parsed strings files grouped into tables and locales
+type targetFunc func(target *internal.Target, manager *localizable.StringsFileManager, set *localizable.LocalizationSet) error
+
+// forEachTarget finds all targets of the workspaces, Xcode projects and Swift packages below root
+// and calls fn for each of them. Targets without bundled strings files are called with no files.
+func forEachTarget(root string, ignorePatterns []string, fn targetFunc) error {
+	targets, err := internal.FindTargets(root, ignorePatterns)
+	if err != nil {
+		return fmt.Errorf("error finding targets: %w", err)
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("no Xcode or Swift package targets found in %s", root)
+	}
+
+	for _, target := range targets {
+		manager, err := localizable.NewStringsFileManager(target.StringsFiles)
+		if err != nil {
+			return fmt.Errorf("error initializing strings manager for target %s: %w", target.Name, err)
+		}
+
+		color.Cyan("\nTarget %s", target.Label())
+
+		set := manager.LocalizationSet(target.DevelopmentRegion)
+		if len(manager.Files) > 0 && len(set.BaseFiles()) == 0 {
+			color.Yellow("No strings files found for base language '%s'", target.DevelopmentRegion)
+		}
+
+		if err := fn(target, manager, set); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
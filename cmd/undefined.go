@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/phillippbertram/xc-strings/internal"
+	"github.com/phillippbertram/xc-strings/internal/constants"
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+type UndefinedOptions struct {
+	stringsPaths     []string
+	swiftDirectories []string
+	ignorePatterns   []string
+	perTarget        bool
+}
+
+var undefinedOptions UndefinedOptions = UndefinedOptions{
+	ignorePatterns: constants.DefaultIgnorePatterns,
+}
+
+var undefinedCmd = &cobra.Command{
+	Use:   "undefined [strings-path] [-d <path to swift code>] [-i <ignore pattern>...]",
+	Short: "Finds keys used in Swift code which are not defined in any .strings file",
+	Long: heredoc.Doc(`
+		Finds localization lookups with a literal key, like NSLocalizedString("key", comment: "")
+		or String(localized: "key"), whose key is not defined in the table it is looked up in.
+		With --targets each target is checked against the strings files bundled with it.
+	`),
+	Example: heredoc.Doc(`
+		undefined App/Resources -d App
+
+		# check each target of the workspace against its own bundled strings files
+		undefined . --targets
+	`),
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stringsPaths, err := requireStringsPaths(args)
+		if err != nil {
+			return err
+		}
+		undefinedOptions.stringsPaths = stringsPaths
+		configStrings(cmd, "swift-dir", &undefinedOptions.swiftDirectories, projectConfig.SourcePaths())
+		configStrings(cmd, "ignore", &undefinedOptions.ignorePatterns, projectConfig.IgnorePatterns())
+
+		if undefinedOptions.perTarget {
+			return forEachTarget(checkRoot(undefinedOptions.stringsPaths), undefinedOptions.ignorePatterns, func(target *internal.Target, manager *localizable.StringsFileManager, set *localizable.LocalizationSet) error {
+				usages, err := internal.FindKeyUsages(target.SourcePaths, undefinedOptions.ignorePatterns, projectConfig.CompiledUsagePatterns())
+				if err != nil {
+					return fmt.Errorf("error finding key lookups: %w", err)
+				}
+				printUndefinedKeys(findUndefinedKeys(lookupsForTarget(target, usages), manager.Files))
+				return nil
+			})
+		}
+
+		manager, err := localizable.NewStringsFileManager(undefinedOptions.stringsPaths)
+		if err != nil {
+			return fmt.Errorf("error initializing strings manager: %w", err)
+		}
+
+		usages, err := internal.FindKeyUsages(undefinedOptions.swiftDirectories, undefinedOptions.ignorePatterns, projectConfig.CompiledUsagePatterns())
+		if err != nil {
+			return fmt.Errorf("error finding key lookups: %w", err)
+		}
+
+		printUndefinedKeys(findUndefinedKeys(usages, manager.Files))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(undefinedCmd)
+	undefinedCmd.Flags().StringSliceVarP(&undefinedOptions.swiftDirectories, "swift-dir", "d", []string{"."}, "Paths to the directories containing Swift files")
+	undefinedCmd.Flags().StringSliceVarP(&undefinedOptions.ignorePatterns, "ignore", "i", constants.DefaultIgnorePatterns, "Glob patterns for files or directories to ignore")
+	undefinedCmd.Flags().BoolVar(&undefinedOptions.perTarget, "targets", false, "Analyze each Xcode or Swift package target separately")
+}
+
+// findUndefinedKeys returns the lookups of keys which are not defined in the table they are looked up in
+func findUndefinedKeys(usages []internal.KeyUsage, files []*localizable.StringsFile) []internal.KeyUsage {
+	keysPerTable := make(map[string]map[string]struct{})
+	for _, file := range files {
+		if keysPerTable[file.Table()] == nil {
+			keysPerTable[file.Table()] = make(map[string]struct{})
+		}
+		for _, key := range file.GetAllKeys() {
+			keysPerTable[file.Table()][key] = struct{}{}
+		}
+	}
+
+	var undefined []internal.KeyUsage
+	for _, usage := range usages {
+		if _, ok := keysPerTable[usage.TableName()][usage.Key]; !ok {
+			undefined = append(undefined, usage)
+		}
+	}
+	return undefined
+}
+
+// lookupsForTarget returns the lookups which are resolved in the bundle of the target:
+// Bundle.module for Swift package targets and the main bundle for Xcode targets.
+func lookupsForTarget(target *internal.Target, usages []internal.KeyUsage) []internal.KeyUsage {
+	var result []internal.KeyUsage
+	for _, usage := range usages {
+		if target.UsesModuleBundle == usage.UsesModuleBundle() && (usage.Bundle == "" || usage.UsesModuleBundle()) {
+			result = append(result, usage)
+		}
+	}
+	return result
+}
+
+func printUndefinedKeys(usages []internal.KeyUsage) {
+	if len(usages) == 0 {
+		color.Green("No undefined keys found. 🚀")
+		return
+	}
+
+	for _, usage := range usages {
+		fmt.Printf("%s:%d: %s (%s)\n", usage.Path, usage.Line, usage.Key, usage.TableName())
+	}
+	color.Red("\nFound %d undefined keys\n", len(usages))
+}
@@ -11,16 +11,19 @@ import (
 	"github.com/phillippbertram/xc-strings/internal"
 	"github.com/phillippbertram/xc-strings/internal/constants"
 	"github.com/phillippbertram/xc-strings/internal/localizable"
+	"github.com/phillippbertram/xc-strings/internal/rules"
 
 	"github.com/spf13/cobra"
 )
 
 type UnusedOptions struct {
-	removeUnused    bool
-	stringsPath     string
-	swiftDirectory  string
-	baseStringsPath string
-	ignorePatterns  []string
+	removeUnused     bool
+	stringsPaths     []string
+	swiftDirectories []string
+	baseStringsPath  string
+	ignorePatterns   []string
+	perTarget        bool
+	format           string
 
 	// TODO: dryRun bool
 }
@@ -30,51 +33,77 @@ var unusedOptions UnusedOptions = UnusedOptions{
 }
 
 var unusedCmd = &cobra.Command{
-	Use:   "unused [strings-path] -b <Localizable.strings> [-d <path to swift code>] [-i <ignore pattern>...]",
+	Use:   "unused [strings-path] [-b <Localizable.strings>] [-d <path to swift code>] [-i <ignore pattern>...]",
 	Short: "Finds unused keys in .strings files",
 	Long: heredoc.Doc(
 		`Check for localization keys defined in a .strings file that are not used in any Swift file within a specified directory.`),
 	Example: heredoc.Doc(`
 		unused -b Localizable.strings
 		unused -b Localizable.strings -d Sources/MyApp -i "Pods/*" "Carthage/*" "*.generated.swift"
+
+		# use the development region of the Xcode project or Swift package to find the base files
+		unused App/Resources -d App
+
+		# find unused keys separately for each Xcode or Swift package target using only the sources of the target
+		unused Packages --targets
 	`),
-	Args: cobra.ExactArgs(1),
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 
-		unusedOptions.stringsPath = args[0]
+		stringsPaths, err := requireStringsPaths(args)
+		if err != nil {
+			return err
+		}
+		unusedOptions.stringsPaths = stringsPaths
+		configString(cmd, "base", &unusedOptions.baseStringsPath, projectConfig.BasePath())
+		configStrings(cmd, "swift-dir", &unusedOptions.swiftDirectories, projectConfig.SourcePaths())
+		configStrings(cmd, "ignore", &unusedOptions.ignorePatterns, projectConfig.IgnorePatterns())
 
-		if unusedOptions.baseStringsPath == "" {
-			return fmt.Errorf("base Localizable.strings file is required")
+		if isMachineReadable(unusedOptions.format) {
+			return reportUnusedKeys(unusedOptions)
 		}
 
-		if unusedOptions.swiftDirectory == "" {
-			unusedOptions.swiftDirectory = "."
+		if unusedOptions.perTarget {
+			return forEachTarget(checkRoot(unusedOptions.stringsPaths), unusedOptions.ignorePatterns, func(target *internal.Target, manager *localizable.StringsFileManager, set *localizable.LocalizationSet) error {
+				keysForBaseStrings := keysForFiles(codeReferencedFiles(set.BaseFiles()))
+				unusedKeys := internal.FindUnusedKeysInSwiftFiles(target.SourcePaths, keysForBaseStrings, unusedOptions.ignorePatterns)
+				printUnusedKeys(unusedKeys)
+
+				mainBundleLookups, err := runRule("moduleBundle", targetRuleContext(target, manager, set, unusedOptions.ignorePatterns))
+				if err != nil {
+					return err
+				}
+				if len(mainBundleLookups) > 0 {
+					printMainBundleLookups(mainBundleLookups)
+				}
+				return nil
+			})
 		}
 
-		manager, err := localizable.NewStringsFileManager([]string{unusedOptions.stringsPath})
+		manager, err := localizable.NewStringsFileManager(unusedOptions.stringsPaths)
 		if err != nil {
 			return fmt.Errorf("error initializing strings manager: %w", err)
 		}
 
+		set, err := resolveLocalizationSet(manager, unusedOptions.baseStringsPath)
+		if err != nil {
+			return err
+		}
+
 		// Start a spinner
 		s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
 		s.Suffix = " Searching for unused keys..."
 		s.Start()
 
-		keysForBaseStrings := manager.GetKeysForFile(unusedOptions.baseStringsPath)
-		unusedKeys := internal.FindUnusedKeysInSwiftFiles(unusedOptions.swiftDirectory, keysForBaseStrings, unusedOptions.ignorePatterns)
+		keysForBaseStrings := keysForFiles(codeReferencedFiles(set.BaseFiles()))
+		unusedKeys := internal.FindUnusedKeysInSwiftFiles(unusedOptions.swiftDirectories, keysForBaseStrings, unusedOptions.ignorePatterns)
 		s.Stop()
 
+		printUnusedKeys(unusedKeys)
 		if len(unusedKeys) == 0 {
-			color.Green("No unused keys found. 🚀")
 			return nil
 		}
 
-		for _, key := range unusedKeys {
-			fmt.Println(key)
-		}
-		color.Red("\nFound %d unused keys\n", len(unusedKeys))
-
 		// TODO: remove unused keys from the .strings file
 		if unusedOptions.removeUnused {
 			color.Yellow("Removing unused is not yet implemented. 🚧")
@@ -86,8 +115,88 @@ var unusedCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(unusedCmd)
-	unusedCmd.Flags().StringVarP(&unusedOptions.baseStringsPath, "base", "b", "", "Path to the base Localizable.strings file which is used as reference for finding unused keys (required)")
-	unusedCmd.Flags().StringVarP(&unusedOptions.swiftDirectory, "swift-dir", "d", "", "Path to the directory containing Swift files (.)")
+	unusedCmd.Flags().StringVarP(&unusedOptions.baseStringsPath, "base", "b", "", "Path to the base Localizable.strings file which is used as reference for finding unused keys (detected from the Xcode project or Swift package if omitted)")
+	unusedCmd.Flags().StringSliceVarP(&unusedOptions.swiftDirectories, "swift-dir", "d", []string{"."}, "Paths to the directories containing Swift files")
 	unusedCmd.Flags().StringSliceVarP(&unusedOptions.ignorePatterns, "ignore", "i", constants.DefaultIgnorePatterns, "Glob patterns for files or directories to ignore")
 	unusedCmd.Flags().BoolVar(&unusedOptions.removeUnused, "remove", false, "Remove unused keys from the .strings file")
+	unusedCmd.Flags().BoolVar(&unusedOptions.perTarget, "targets", false, "Analyze each Xcode or Swift package target separately")
+	addFormatFlag(unusedCmd, &unusedOptions.format)
+}
+
+// reportUnusedKeys writes the unused keys, and lookups missing `bundle: .module` per target, as machine-readable report
+func reportUnusedKeys(opts UnusedOptions) error {
+	var diagnostics []rules.Diagnostic
+
+	if opts.perTarget {
+		err := forEachTarget(checkRoot(opts.stringsPaths), opts.ignorePatterns, func(target *internal.Target, manager *localizable.StringsFileManager, set *localizable.LocalizationSet) error {
+			ctx := targetRuleContext(target, manager, set, opts.ignorePatterns)
+			for _, id := range []string{"unused", "moduleBundle"} {
+				ruleDiagnostics, err := runRule(id, ctx)
+				if err != nil {
+					return err
+				}
+				diagnostics = append(diagnostics, ruleDiagnostics...)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		manager, err := localizable.NewStringsFileManager(opts.stringsPaths)
+		if err != nil {
+			return fmt.Errorf("error initializing strings manager: %w", err)
+		}
+
+		set, err := resolveLocalizationSet(manager, opts.baseStringsPath)
+		if err != nil {
+			return err
+		}
+
+		diagnostics, err = runRule("unused", &rules.Context{
+			Files:          manager.Files,
+			Set:            set,
+			SourcePaths:    opts.swiftDirectories,
+			IgnorePatterns: opts.ignorePatterns,
+			Config:         projectConfig,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeReport(opts.format, "unused", diagnostics)
+}
+
+func printUnusedKeys(unusedKeys []string) {
+	if len(unusedKeys) == 0 {
+		color.Green("No unused keys found. 🚀")
+		return
+	}
+
+	for _, key := range unusedKeys {
+		fmt.Println(key)
+	}
+	color.Red("\nFound %d unused keys\n", len(unusedKeys))
+}
+
+// targetRuleContext returns the context to run the rules of `unused` for a target
+func targetRuleContext(target *internal.Target, manager *localizable.StringsFileManager, set *localizable.LocalizationSet, ignorePatterns []string) *rules.Context {
+	return &rules.Context{
+		Files:          manager.Files,
+		Set:            set,
+		SourcePaths:    target.SourcePaths,
+		IgnorePatterns: ignorePatterns,
+		Target:         target,
+		UsagePatterns:  projectConfig.CompiledUsagePatterns(),
+		Config:         projectConfig,
+	}
+}
+
+// printMainBundleLookups prints the lookups reported by the moduleBundle rule
+func printMainBundleLookups(lookups []rules.Diagnostic) {
+	color.Yellow("Keys looked up without `bundle: .module` (%d):", len(lookups))
+	for _, lookup := range lookups {
+		fmt.Printf("%s: %s\n", lookup.Location(), lookup.Key)
+	}
 }
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/fatih/color"
+	"github.com/phillippbertram/xc-strings/internal"
+	"github.com/phillippbertram/xc-strings/internal/constants"
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+type ValuesOptions struct {
+	baseStringsPath  string
+	stringsPaths     []string
+	swiftDirectories []string
+	ignorePatterns   []string
+	duplicates       bool
+	planPath         string
+}
+
+var valuesOptions ValuesOptions = ValuesOptions{
+	ignorePatterns: constants.DefaultIgnorePatterns,
+}
+
+var valuesCmd = &cobra.Command{
+	Use:   "values [strings-path] [-b <base Localizable.strings>] [--duplicates] [--plan <path>]",
+	Short: "List the base values and the keys they are defined under",
+	Long: heredoc.Doc(`
+		Lists the values of the base files and the number of keys each value is defined under.
+		With --duplicates only values defined under several keys of a table are listed, together with
+		the number of lookups of each key in the Swift code. Folding them into one key saves translations
+		and keeps the wording consistent.
+
+		The rename plan proposes the key used most often in code as canonical key of each value
+		and lists the locales in which the keys are translated differently.
+	`),
+	Example: heredoc.Doc(`
+		# list the values which are defined under several keys
+		xcs values App/Resources -b App/Resources/en.lproj/Localizable.strings --duplicates -d App
+
+		# write a plan to fold the keys of each value into one canonical key
+		xcs values App/Resources --duplicates --plan renames.yml
+	`),
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stringsPaths, err := requireStringsPaths(args)
+		if err != nil {
+			return err
+		}
+		valuesOptions.stringsPaths = stringsPaths
+		configString(cmd, "base", &valuesOptions.baseStringsPath, projectConfig.BasePath())
+		configStrings(cmd, "swift-dir", &valuesOptions.swiftDirectories, projectConfig.SourcePaths())
+		configStrings(cmd, "ignore", &valuesOptions.ignorePatterns, projectConfig.IgnorePatterns())
+
+		if valuesOptions.planPath != "" && !valuesOptions.duplicates {
+			return fmt.Errorf("--plan requires --duplicates")
+		}
+
+		manager, err := localizable.NewStringsFileManager(valuesOptions.stringsPaths)
+		if err != nil {
+			return fmt.Errorf("error initializing strings manager: %w", err)
+		}
+
+		set, err := resolveLocalizationSet(manager, valuesOptions.baseStringsPath)
+		if err != nil {
+			return err
+		}
+
+		if !valuesOptions.duplicates {
+			printValues(set)
+			return nil
+		}
+		return findDuplicateValues(set, valuesOptions)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(valuesCmd)
+	valuesCmd.Flags().StringVarP(&valuesOptions.baseStringsPath, "base", "b", "", "Path to the base Localizable.strings file (detected from the Xcode project or Swift package if omitted)")
+	valuesCmd.Flags().StringSliceVarP(&valuesOptions.swiftDirectories, "swift-dir", "d", []string{"."}, "Paths to the directories containing Swift files")
+	valuesCmd.Flags().StringSliceVarP(&valuesOptions.ignorePatterns, "ignore", "i", constants.DefaultIgnorePatterns, "Glob patterns for files or directories to ignore")
+	valuesCmd.Flags().BoolVar(&valuesOptions.duplicates, "duplicates", false, "List only values which are defined under several keys of a table")
+	valuesCmd.Flags().StringVar(&valuesOptions.planPath, "plan", "", "Write a YAML plan to rename the keys of each duplicate value to one canonical key")
+}
+
+// renamePlan folds the keys of each duplicate value into one canonical key
+type renamePlan struct {
+	Renames []keyRename `yaml:"renames"`
+}
+
+type keyRename struct {
+	Table            string   `yaml:"table"` // path of the base file
+	Value            string   `yaml:"value"`
+	Key              string   `yaml:"key"`
+	Replaces         []string `yaml:"replaces"`
+	DivergingLocales []string `yaml:"divergingLocales,omitempty"`
+}
+
+func printValues(set *localizable.LocalizationSet) {
+	for _, table := range set.Tables {
+		base := table.Base()
+		if base == nil {
+			continue
+		}
+
+		var values []string
+		keyCounts := make(map[string]int)
+		for _, entry := range base.Entries() {
+			value := entry.RawValue()
+			if keyCounts[value] == 0 {
+				values = append(values, value)
+			}
+			keyCounts[value]++
+		}
+		sort.Strings(values)
+
+		fmt.Println(base.File.Path)
+		for _, value := range values {
+			fmt.Printf("  %q (%d keys)\n", value, keyCounts[value])
+		}
+	}
+}
+
+func findDuplicateValues(set *localizable.LocalizationSet, opts ValuesOptions) error {
+	usages, err := internal.FindKeyUsages(opts.swiftDirectories, opts.ignorePatterns, projectConfig.CompiledUsagePatterns())
+	if err != nil {
+		return fmt.Errorf("error scanning Swift files: %w", err)
+	}
+	usageCounts := make(map[string]int) // by table and key
+	for _, usage := range usages {
+		usageCounts[usage.TableName()+"\x00"+usage.Key]++
+	}
+
+	plan := renamePlan{}
+	found := 0
+	for _, table := range set.Tables {
+		base := table.Base()
+		if base == nil || !base.File.IsReferencedInCode() {
+			continue
+		}
+
+		for _, duplicate := range table.DuplicateValues() {
+			counts := make(map[string]int)
+			for _, key := range duplicate.Keys {
+				counts[key] = usageCounts[table.Name+"\x00"+key]
+			}
+			canonical := canonicalKey(duplicate.Keys, counts)
+			diverging := duplicate.DivergingLocales
+
+			fmt.Printf("%q is defined under %d keys in %s\n", duplicate.Value, len(duplicate.Keys), base.File.Path)
+			for _, key := range duplicate.Keys {
+				marker := " "
+				if key == canonical {
+					marker = "*"
+				}
+				fmt.Printf("  %s %s (%d usages)\n", marker, key, counts[key])
+			}
+			if len(diverging) > 0 {
+				color.Yellow("    translated differently in %s\n", strings.Join(diverging, ", "))
+			}
+			found++
+
+			rename := keyRename{Table: base.File.Path, Value: duplicate.Value, Key: canonical, DivergingLocales: diverging}
+			for _, key := range duplicate.Keys {
+				if key != canonical {
+					rename.Replaces = append(rename.Replaces, key)
+				}
+			}
+			plan.Renames = append(plan.Renames, rename)
+		}
+	}
+
+	if found == 0 {
+		color.Green("No values defined under several keys found. 🚀")
+		return nil
+	}
+	color.Yellow("\nFound %d values defined under several keys, * marks the proposed canonical key\n", found)
+
+	if opts.planPath == "" {
+		return nil
+	}
+	content, err := yaml.Marshal(plan)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(opts.planPath, content, 0o644); err != nil {
+		return fmt.Errorf("error writing rename plan: %w", err)
+	}
+	color.Green("Rename plan written to %s", opts.planPath)
+	return nil
+}
+
+// canonicalKey returns the key the other keys of a value are folded into:
+// the key used most often in code, then the shortest key, then the first in alphabetical order
+func canonicalKey(keys []string, usageCounts map[string]int) string {
+	sorted := append([]string(nil), keys...)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		switch {
+		case usageCounts[a] != usageCounts[b]:
+			return usageCounts[a] > usageCounts[b]
+		case len(a) != len(b):
+			return len(a) < len(b)
+		default:
+			return a < b
+		}
+	})
+	return sorted[0]
+}
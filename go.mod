@@ -5,7 +5,10 @@ go 1.21.6
 require (
 	github.com/MakeNowJust/heredoc v1.0.0
 	github.com/briandowns/spinner v1.23.0
+	github.com/rivo/uniseg v0.4.7
 	github.com/spf13/cobra v1.8.0
+	golang.org/x/text v0.14.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,210 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/phillippbertram/xc-strings/internal/rules"
+)
+
+// FileName is the default name of the baseline file
+const FileName = ".xcstrings-baseline.json"
+
+// schemaVersion is incremented whenever the structure of the baseline file changes incompatibly
+const schemaVersion = 1
+
+// Baseline holds known diagnostics which are not reported by `xcs check`.
+// Entries are identified by rule, file and key instead of line numbers, so that editing a file does not invalidate them.
+type Baseline struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+
+	path      string
+	remaining map[fingerprint]int
+}
+
+// Entry is a known diagnostic. Count is the number of diagnostics with the same fingerprint, e.g. for keys defined three times.
+type Entry struct {
+	Rule    string `json:"rule"`
+	Path    string `json:"path"` // relative to the baseline file
+	Key     string `json:"key,omitempty"`
+	Message string `json:"message,omitempty"` // only used to identify diagnostics without a key
+	Count   int    `json:"count"`
+}
+
+type fingerprint struct {
+	rule, path, key, message string
+}
+
+func (e Entry) fingerprint() fingerprint {
+	return fingerprint{rule: e.Rule, path: e.Path, key: e.Key, message: e.Message}
+}
+
+// New creates a baseline at path containing the given diagnostics
+func New(path string, diagnostics []rules.Diagnostic) *Baseline {
+	b := &Baseline{Version: schemaVersion, path: path}
+
+	counts := make(map[fingerprint]int)
+	var fingerprints []fingerprint
+	for _, diagnostic := range diagnostics {
+		f := b.fingerprint(diagnostic)
+		if counts[f] == 0 {
+			fingerprints = append(fingerprints, f)
+		}
+		counts[f]++
+	}
+
+	b.Entries = make([]Entry, 0, len(fingerprints))
+	for _, f := range fingerprints {
+		b.Entries = append(b.Entries, Entry{Rule: f.rule, Path: f.path, Key: f.key, Message: f.message, Count: counts[f]})
+	}
+	b.sortEntries()
+	b.reset()
+	return b
+}
+
+// Retain adds the entries of the previous baseline whose rules did not run, so that updating the baseline
+// with a selection of rules keeps the known issues of the other rules
+func (b *Baseline) Retain(previous *Baseline, ranRules []string) {
+	if previous == nil {
+		return
+	}
+	for _, entry := range previous.Entries {
+		if !contains(ranRules, entry.Rule) {
+			b.Entries = append(b.Entries, entry)
+		}
+	}
+	b.sortEntries()
+	b.reset()
+}
+
+// Load reads the baseline file at path
+func Load(path string) (*Baseline, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Baseline{}
+	if err := json.Unmarshal(content, b); err != nil {
+		return nil, fmt.Errorf("error parsing baseline %s: %w", path, err)
+	}
+	if b.Version != schemaVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d in %s, run `xcs check --update-baseline` to recreate it", b.Version, path)
+	}
+
+	b.path = path
+	b.reset()
+	return b, nil
+}
+
+// Save writes the baseline to its path
+func (b *Baseline) Save() error {
+	content, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(b.path, append(content, '\n'), 0o644)
+}
+
+// Path returns the path of the baseline file
+func (b *Baseline) Path() string {
+	return b.path
+}
+
+// Filter returns the diagnostics which are not part of the baseline. A nil baseline returns all diagnostics.
+// Each entry suppresses at most Count diagnostics, also across several calls.
+func (b *Baseline) Filter(diagnostics []rules.Diagnostic) []rules.Diagnostic {
+	if b == nil {
+		return diagnostics
+	}
+
+	var result []rules.Diagnostic
+	for _, diagnostic := range diagnostics {
+		f := b.fingerprint(diagnostic)
+		if b.remaining[f] > 0 {
+			b.remaining[f]--
+			continue
+		}
+		result = append(result, diagnostic)
+	}
+	return result
+}
+
+// Fixed returns the entries of the given rules which did not match any diagnostic passed to Filter,
+// with the number of diagnostics which have been fixed. Entries of rules which did not run are not fixed.
+func (b *Baseline) Fixed(ranRules []string) []Entry {
+	if b == nil {
+		return nil
+	}
+
+	var fixed []Entry
+	for _, entry := range b.Entries {
+		if !contains(ranRules, entry.Rule) {
+			continue
+		}
+		if remaining := b.remaining[entry.fingerprint()]; remaining > 0 {
+			entry.Count = remaining
+			fixed = append(fixed, entry)
+		}
+	}
+	return fixed
+}
+
+func (b *Baseline) sortEntries() {
+	sort.SliceStable(b.Entries, func(i, j int) bool {
+		a, c := b.Entries[i], b.Entries[j]
+		if a.Path != c.Path {
+			return a.Path < c.Path
+		}
+		if a.Rule != c.Rule {
+			return a.Rule < c.Rule
+		}
+		return a.Key < c.Key
+	})
+}
+
+func (b *Baseline) reset() {
+	b.remaining = make(map[fingerprint]int)
+	for _, entry := range b.Entries {
+		b.remaining[entry.fingerprint()] += entry.Count
+	}
+}
+
+// fingerprint identifies a diagnostic by rule, path relative to the baseline and key.
+// Diagnostics without a key, like unsanitized comment lines, are identified by their message.
+func (b *Baseline) fingerprint(diagnostic rules.Diagnostic) fingerprint {
+	f := fingerprint{rule: diagnostic.RuleID, path: b.relativePath(diagnostic.Path), key: diagnostic.Key}
+	if f.key == "" {
+		f.message = diagnostic.Message
+	}
+	return f
+}
+
+func (b *Baseline) relativePath(path string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	absDir, err := filepath.Abs(filepath.Dir(b.path))
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(absDir, absPath)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
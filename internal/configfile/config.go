@@ -0,0 +1,364 @@
+package configfile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the project configuration file
+const FileName = ".xcstrings.yml"
+
+// SeverityOff disables a rule
+const SeverityOff = "off"
+
+// Config is the project configuration read from .xcstrings.yml.
+// All paths are relative to the directory of the configuration file.
+type Config struct {
+	Base          string                `yaml:"base,omitempty"`          // base strings file
+	Strings       []string              `yaml:"strings,omitempty"`       // strings files, directories or glob patterns
+	Sources       []string              `yaml:"sources,omitempty"`       // directories containing Swift sources
+	Ignore        []string              `yaml:"ignore,omitempty"`        // glob patterns for files or directories to ignore
+	Rules         map[string]RuleConfig `yaml:"rules,omitempty"`         // severity and options of each rule
+	UsagePatterns []string              `yaml:"usagePatterns,omitempty"` // regular expressions whose first group captures a key used in code
+	Overrides     []Override            `yaml:"overrides,omitempty"`     // rule settings for specific paths
+	Baseline      string                `yaml:"baseline,omitempty"`      // baseline file of known issues
+	Glossary      string                `yaml:"glossary,omitempty"`      // glossary file of approved and forbidden terms
+
+	// Path is the path of the loaded configuration file
+	Path string `yaml:"-"`
+}
+
+// Override changes the rule settings for all files matching one of the paths
+type Override struct {
+	Paths []string              `yaml:"paths"`
+	Rules map[string]RuleConfig `yaml:"rules"`
+}
+
+// RuleConfig is either just a severity (error, warning, info, off)
+// or a mapping with a severity and rule specific options.
+type RuleConfig struct {
+	Severity string
+	options  *yaml.Node
+}
+
+func (c *RuleConfig) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		c.Severity = node.Value
+		return nil
+	}
+
+	var settings struct {
+		Severity string `yaml:"severity"`
+	}
+	if err := node.Decode(&settings); err != nil {
+		return err
+	}
+	c.Severity = settings.Severity
+	c.options = node
+	return nil
+}
+
+func (c RuleConfig) MarshalYAML() (any, error) {
+	if c.options != nil {
+		return c.options, nil
+	}
+	return c.Severity, nil
+}
+
+// Decode decodes the rule specific options into v. It does nothing if the rule has no options.
+func (c RuleConfig) Decode(v any) error {
+	if c.options == nil {
+		return nil
+	}
+	return c.options.Decode(v)
+}
+
+// Find walks up from startDir and returns the path of the first configuration file found
+func Find(startDir string) (string, error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		path := filepath.Join(dir, FileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", os.ErrNotExist
+		}
+		dir = parent
+	}
+}
+
+// Load reads the configuration file at path
+func Load(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{}
+	if err := yaml.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	config.Path = path
+
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration %s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// FindAndLoad loads the configuration file above startDir. It returns nil if there is none.
+func FindAndLoad(startDir string) (*Config, error) {
+	path, err := Find(startDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Load(path)
+}
+
+const header = `# xcs project configuration. Paths are relative to this file.
+# Rules accept a severity (error, warning, info, off) or a mapping with a severity and rule options.
+# Overrides change rule settings for matching paths, e.g.
+#
+# overrides:
+#   - paths: ["Packages/**"]
+#     rules:
+#       unused: off
+#
+# usagePatterns are regular expressions whose first group captures a key used in code, e.g. 'L10n\.tr\("([^"]+)"'
+
+`
+
+// Save writes the configuration to path
+func (c *Config) Save(path string) error {
+	content, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append([]byte(header), content...), 0o644)
+}
+
+func (c *Config) validate() error {
+	validateRules := func(rules map[string]RuleConfig) error {
+		for id, rule := range rules {
+			switch rule.Severity {
+			case "", "error", "warning", "info", SeverityOff:
+			default:
+				return fmt.Errorf("unknown severity '%s' for rule %s", rule.Severity, id)
+			}
+		}
+		return nil
+	}
+
+	if err := validateRules(c.Rules); err != nil {
+		return err
+	}
+	for _, override := range c.Overrides {
+		if err := validateRules(override.Rules); err != nil {
+			return err
+		}
+	}
+	for _, pattern := range c.UsagePatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid usage pattern '%s': %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Dir returns the directory of the configuration file
+func (c *Config) Dir() string {
+	return filepath.Dir(c.Path)
+}
+
+// ResolvePath converts a path relative to the configuration file into a path relative to the working directory
+func (c *Config) ResolvePath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	absPath := filepath.Join(c.Dir(), path)
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, absPath); err == nil {
+			return rel
+		}
+	}
+	return absPath
+}
+
+// ResolvePaths resolves all paths with ResolvePath
+func (c *Config) ResolvePaths(paths []string) []string {
+	resolved := make([]string, 0, len(paths))
+	for _, path := range paths {
+		resolved = append(resolved, c.ResolvePath(path))
+	}
+	return resolved
+}
+
+// BasePath returns the base strings file relative to the working directory, or an empty string
+func (c *Config) BasePath() string {
+	if c == nil || c.Base == "" {
+		return ""
+	}
+	return c.ResolvePath(c.Base)
+}
+
+// StringsPaths returns the strings paths relative to the working directory
+func (c *Config) StringsPaths() []string {
+	if c == nil {
+		return nil
+	}
+	return c.ResolvePaths(c.Strings)
+}
+
+// SourcePaths returns the Swift source directories relative to the working directory
+func (c *Config) SourcePaths() []string {
+	if c == nil {
+		return nil
+	}
+	return c.ResolvePaths(c.Sources)
+}
+
+// BaselinePath returns the baseline file relative to the working directory, or an empty string
+func (c *Config) BaselinePath() string {
+	if c == nil || c.Baseline == "" {
+		return ""
+	}
+	return c.ResolvePath(c.Baseline)
+}
+
+// GlossaryPath returns the glossary file relative to the working directory, or an empty string
+func (c *Config) GlossaryPath() string {
+	if c == nil || c.Glossary == "" {
+		return ""
+	}
+	return c.ResolvePath(c.Glossary)
+}
+
+// IgnorePatterns returns the configured ignore patterns
+func (c *Config) IgnorePatterns() []string {
+	if c == nil {
+		return nil
+	}
+	return c.Ignore
+}
+
+// CompiledUsagePatterns returns the compiled usage patterns. They are validated when the configuration is loaded.
+func (c *Config) CompiledUsagePatterns() []*regexp.Regexp {
+	if c == nil {
+		return nil
+	}
+	patterns := make([]*regexp.Regexp, 0, len(c.UsagePatterns))
+	for _, pattern := range c.UsagePatterns {
+		patterns = append(patterns, regexp.MustCompile(pattern))
+	}
+	return patterns
+}
+
+// RuleConfig returns the configuration of a rule for a file. Overrides matching the path take precedence.
+func (c *Config) RuleConfig(ruleID string, path string) (RuleConfig, bool) {
+	if c == nil {
+		return RuleConfig{}, false
+	}
+
+	config, found := c.Rules[ruleID]
+	if path == "" {
+		return config, found
+	}
+
+	relPath := c.relativePath(path)
+	for _, override := range c.Overrides {
+		overrideConfig, ok := override.Rules[ruleID]
+		if ok && matchesAny(override.Paths, relPath) {
+			if overrideConfig.Severity == "" {
+				overrideConfig.Severity = config.Severity
+			}
+			config, found = overrideConfig, true
+		}
+	}
+	return config, found
+}
+
+// IsRuleDisabled reports whether the rule is turned off and not enabled again by any override
+func (c *Config) IsRuleDisabled(ruleID string) bool {
+	config, ok := c.RuleConfig(ruleID, "")
+	if !ok || config.Severity != SeverityOff {
+		return false
+	}
+	for _, override := range c.Overrides {
+		if overrideConfig, ok := override.Rules[ruleID]; ok && overrideConfig.Severity != SeverityOff {
+			return false
+		}
+	}
+	return true
+}
+
+// relativePath converts a path relative to the working directory into a path relative to the configuration file
+func (c *Config) relativePath(path string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(c.Dir(), absPath)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// matchesAny reports whether the path matches one of the glob patterns. `**` matches any number of directories.
+// Patterns without wildcards also match all files inside of the directory.
+func matchesAny(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		pattern = filepath.ToSlash(filepath.Clean(pattern))
+		if !strings.ContainsAny(pattern, "*?[") && (path == pattern || strings.HasPrefix(path, pattern+"/")) {
+			return true
+		}
+		if globToRegexp(pattern).MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func globToRegexp(pattern string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if strings.HasPrefix(pattern[i:], "**/") {
+				// "**/" also matches no directory at all
+				sb.WriteString("(?:.*/)?")
+				i += 2
+			} else if strings.HasPrefix(pattern[i:], "**") {
+				sb.WriteString(".*")
+				i++
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
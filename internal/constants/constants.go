@@ -12,5 +12,8 @@ var DefaultIgnorePatterns = []string{
 
 const DefaultStringsGlob = "*.strings"
 
+// InfoPlistTable is the strings table whose keys are read by the system instead of being referenced in code
+const InfoPlistTable = "InfoPlist"
+
 const GithubPage = "https://github.com/phillippbertram/go-xc-strings"
 const GithubReleasesPage = "https://github.com/phillippbertram/go-xc-strings/releases"
@@ -0,0 +1,225 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change
+const contextLines = 3
+
+type operation int
+
+const (
+	equal operation = iota
+	deleted
+	inserted
+)
+
+type edit struct {
+	op   operation
+	line string
+}
+
+// Unified returns the changes between two texts in the unified diff format, or an empty string if they are equal
+func Unified(fromName string, toName string, from string, to string) string {
+	if from == to {
+		return ""
+	}
+
+	edits := lineEdits(splitLines(from), splitLines(to))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+	for _, h := range hunks(edits) {
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(h.fromStart, h.fromCount), hunkRange(h.toStart, h.toCount))
+		for _, e := range h.edits {
+			switch e.op {
+			case equal:
+				sb.WriteString(" " + e.line + "\n")
+			case deleted:
+				sb.WriteString("-" + e.line + "\n")
+			case inserted:
+				sb.WriteString("+" + e.line + "\n")
+			}
+		}
+	}
+	return sb.String()
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+func hunkRange(start int, count int) string {
+	if count == 0 {
+		// an empty range refers to the line before
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+type hunk struct {
+	fromStart, fromCount int
+	toStart, toCount     int
+	edits                []edit
+}
+
+// hunks groups the edits into changes with up to three lines of context. Changes with less than
+// twice the context in between are merged.
+func hunks(edits []edit) []hunk {
+	var result []hunk
+	fromLine, toLine := 1, 1
+	for i := 0; i < len(edits); {
+		if edits[i].op == equal {
+			fromLine++
+			toLine++
+			i++
+			continue
+		}
+
+		// start the hunk with the preceding context
+		start := i
+		for start > 0 && i-start < contextLines && edits[start-1].op == equal {
+			start--
+		}
+		h := hunk{fromStart: fromLine - (i - start), toStart: toLine - (i - start)}
+
+		end := i
+		for end < len(edits) {
+			if edits[end].op != equal {
+				end++
+				continue
+			}
+			// the hunk ends if the next change is further away than twice the context
+			next := end
+			for next < len(edits) && edits[next].op == equal {
+				next++
+			}
+			if next == len(edits) || next-end > 2*contextLines {
+				end += min(contextLines, next-end)
+				break
+			}
+			end = next
+		}
+
+		h.edits = edits[start:end]
+		for _, e := range h.edits {
+			if e.op != inserted {
+				h.fromCount++
+			}
+			if e.op != deleted {
+				h.toCount++
+			}
+		}
+		for _, e := range edits[i:end] {
+			if e.op != inserted {
+				fromLine++
+			}
+			if e.op != deleted {
+				toLine++
+			}
+		}
+		result = append(result, h)
+		i = end
+	}
+	return result
+}
+
+// lineEdits returns the shortest edit script from a to b with the algorithm of Eugene W. Myers.
+// Common lines at the start and the end are skipped, because most changes only touch a few lines.
+func lineEdits(a []string, b []string) []edit {
+	var prefix, suffix []edit
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		prefix = append(prefix, edit{op: equal, line: a[0]})
+		a, b = a[1:], b[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		suffix = append([]edit{{op: equal, line: a[len(a)-1]}}, suffix...)
+		a, b = a[:len(a)-1], b[:len(b)-1]
+	}
+
+	edits := append(prefix, shortestEdits(a, b)...)
+	return append(edits, suffix...)
+}
+
+func shortestEdits(a []string, b []string) []edit {
+	n, m := len(a), len(b)
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+
+	// trace[d] holds the furthest reaching x of the diagonals -d-1...d+1 before step d
+	var trace [][]int
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrack follows the furthest reaching paths of each step back from the end to the start
+func backtrack(trace [][]int, a []string, b []string) []edit {
+	var edits []edit
+	x, y := len(a), len(b)
+
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		at := func(k int) int { return v[k+d+1] }
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, edit{op: equal, line: a[x]})
+		}
+		if x == prevX {
+			y--
+			edits = append(edits, edit{op: inserted, line: b[y]})
+		} else {
+			x--
+			edits = append(edits, edit{op: deleted, line: a[x]})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		edits = append(edits, edit{op: equal, line: a[x]})
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
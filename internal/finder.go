@@ -8,14 +8,19 @@ import (
 	"strings"
 )
 
-func FindUnusedKeysInSwiftFiles(directory string, keys []string, ignorePatterns []string) []string {
+func FindUnusedKeysInSwiftFiles(directories []string, keys []string, ignorePatterns []string) []string {
 	// fmt.Println("Searching for keys in Swift files...")
 	// fmt.Println("Directory:", directory)
 	// fmt.Println("Keys:", len(keys))
 	// fmt.Println("Ignore patterns: ", ignorePatterns)
 
 	keysMap := SliceToMap(keys) // more performant
-	usedKeys := findKeysInSwiftFiles(directory, keys, ignorePatterns)
+	usedKeys := make(map[string]struct{})
+	for _, directory := range directories {
+		for key := range findKeysInSwiftFiles(directory, keys, ignorePatterns) {
+			usedKeys[key] = struct{}{}
+		}
+	}
 
 	// get unused keys
 	unusedKeys := make(map[string]struct{})
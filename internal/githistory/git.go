@@ -0,0 +1,72 @@
+package githistory
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Commit is a commit which changed a file
+type Commit struct {
+	Hash string
+	Time time.Time
+}
+
+// ShortHash returns the abbreviated commit hash
+func (c Commit) ShortHash() string {
+	if len(c.Hash) < 7 {
+		return c.Hash
+	}
+	return c.Hash[:7]
+}
+
+// IsRepository reports whether dir is located in a git work tree and git is installed
+func IsRepository(dir string) bool {
+	output, err := run(dir, "rev-parse", "--is-inside-work-tree")
+	return err == nil && strings.TrimSpace(string(output)) == "true"
+}
+
+// Log returns the commits which changed the file, newest first.
+// It returns no commits for files which are not tracked by git.
+func Log(path string) ([]Commit, error) {
+	output, err := run(filepath.Dir(path), "log", "--format=%H %ct", "--", filepath.Base(path))
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []Commit
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		hash, timestamp, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+		seconds, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("git log: unexpected output %q", scanner.Text())
+		}
+		commits = append(commits, Commit{Hash: hash, Time: time.Unix(seconds, 0)})
+	}
+	return commits, scanner.Err()
+}
+
+// Show returns the content of the file at the given commit
+func Show(commit string, path string) ([]byte, error) {
+	return run(filepath.Dir(path), "show", fmt.Sprintf("%s:./%s", commit, filepath.Base(path)))
+}
+
+func run(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(stderr.String()))
+	}
+	return output, nil
+}
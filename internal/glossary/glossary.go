@@ -0,0 +1,102 @@
+package glossary
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the default name of the glossary file next to .xcstrings.yml
+const FileName = ".xcstrings-glossary.yml"
+
+// Glossary maps terms of the base language to their approved translations:
+//
+//	terms:
+//	  - term: Sign in
+//	    translations: { de: Anmelden, fr: Se connecter }
+//	    forbidden: { de: [Einloggen, Login] }
+//	  - term: Acme Cloud
+//	    keep: true          # product names are not translated
+//	forbidden:
+//	  de: [Handy]           # terms which must not appear in any value of the locale
+type Glossary struct {
+	Terms     []Term              `yaml:"terms"`
+	Forbidden map[string][]string `yaml:"forbidden"`
+
+	path string
+}
+
+// Term is a term of the base language. Locales are locale codes like "de" or "pt-BR",
+// a language applies to all of its regions unless the region has its own entry.
+type Term struct {
+	Term         string              `yaml:"term"`
+	Translations map[string]string   `yaml:"translations"`
+	Forbidden    map[string][]string `yaml:"forbidden"`
+	Keep         bool                `yaml:"keep"` // the term must appear unchanged in all translations
+}
+
+// Load reads the glossary file at path
+func Load(path string) (*Glossary, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Glossary{}
+	if err := yaml.Unmarshal(content, g); err != nil {
+		return nil, fmt.Errorf("error parsing glossary %s: %w", path, err)
+	}
+	for i, term := range g.Terms {
+		if strings.TrimSpace(term.Term) == "" {
+			return nil, fmt.Errorf("invalid glossary %s: term %d is empty", path, i+1)
+		}
+	}
+
+	g.path = path
+	return g, nil
+}
+
+// Path returns the path of the glossary file
+func (g *Glossary) Path() string {
+	return g.path
+}
+
+// Approved returns the approved translation of the term for a locale, or an empty string
+func (t Term) Approved(locale string) string {
+	if t.Keep {
+		return t.Term
+	}
+	return forLocale(t.Translations, locale)
+}
+
+// ForbiddenTerms returns the terms which must not be used for the term in a locale
+func (t Term) ForbiddenTerms(locale string) []string {
+	return forLocale(t.Forbidden, locale)
+}
+
+// ForbiddenTerms returns the terms which must not appear in any value of a locale
+func (g *Glossary) ForbiddenTerms(locale string) []string {
+	return forLocale(g.Forbidden, locale)
+}
+
+// forLocale returns the value for a locale code like "pt-BR", falling back to its language "pt"
+func forLocale[V any](values map[string]V, locale string) V {
+	normalized := strings.ReplaceAll(locale, "_", "-")
+	for code, value := range values {
+		if strings.EqualFold(strings.ReplaceAll(code, "_", "-"), normalized) {
+			return value
+		}
+	}
+
+	language, _, _ := strings.Cut(normalized, "-")
+	for code, value := range values {
+		if strings.EqualFold(code, language) {
+			return value
+		}
+	}
+
+	var zero V
+	return zero
+}
@@ -0,0 +1,85 @@
+package glossary
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxInflectionLength is the number of characters an inflected word may add to a term, like "accounts" for "account"
+const maxInflectionLength = 3
+
+// inflectionSuffixes are the endings by which a forbidden term may be inflected, like "Handys" for "Handy"
+var inflectionSuffixes = []string{"s", "es", "n", "en", "e", "er"}
+
+// Contains reports whether text contains term, ignoring case and inflection of its words,
+// so that "Accounts" contains "account" and "Dateien" contains "Datei".
+// The tolerance is meant for approved terms, forbidden terms are matched with ContainsWord.
+func Contains(text string, term string) bool {
+	return containsMatching(text, term, wordsMatch)
+}
+
+// ContainsWord reports whether text contains term as whole words ignoring case,
+// or with a plain inflection suffix, so that "Handys" contains "Handy" but "Hand" and "Handel" do not
+func ContainsWord(text string, term string) bool {
+	return containsMatching(text, term, func(word string, termWord string) bool {
+		if word == termWord {
+			return true
+		}
+		for _, suffix := range inflectionSuffixes {
+			if word == termWord+suffix {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func containsMatching(text string, term string, match func(word string, termWord string) bool) bool {
+	words := splitWords(text)
+	termWords := splitWords(term)
+	if len(termWords) == 0 {
+		return false
+	}
+
+	for i := 0; i+len(termWords) <= len(words); i++ {
+		matched := true
+		for j, termWord := range termWords {
+			if !match(words[i+j], termWord) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func splitWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// wordsMatch reports whether a word is the term word or an inflection of it.
+// The final letter of longer term words may change, like "company" and "companies".
+func wordsMatch(word string, termWord string) bool {
+	if word == termWord {
+		return true
+	}
+
+	stems := []string{termWord}
+	if utf8.RuneCountInString(termWord) >= 5 {
+		_, size := utf8.DecodeLastRuneInString(termWord)
+		stems = append(stems, termWord[:len(termWord)-size])
+	}
+	for _, stem := range stems {
+		if utf8.RuneCountInString(stem) >= 3 && strings.HasPrefix(word, stem) &&
+			utf8.RuneCountInString(word)-utf8.RuneCountInString(stem) <= maxInflectionLength {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,299 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// BaseLanguage is the pseudo language of Base.lproj which holds Interface Builder files
+const BaseLanguage = "Base"
+
+var knownRegionsRegex = regexp.MustCompile(`(?s)knownRegions\s*=\s*\(([^)]*)\)`)
+
+var ErrNoDeclaredLanguages = errors.New("no languages declared in an Xcode project or String Catalog")
+
+// DeclaredLanguages are the languages a project declares to support
+type DeclaredLanguages struct {
+	DevelopmentRegion string
+	Languages         []string // sorted, including the development region
+	Sources           []string // paths of the project and String Catalogs the languages were read from
+}
+
+// LocalizationDirectory is an .lproj directory on disk
+type LocalizationDirectory struct {
+	Language string
+	Path     string
+	Tables   []string // names of .strings and .stringsdict files without extension
+}
+
+// LanguageReport is the result of comparing declared languages with the .lproj directories on disk
+type LanguageReport struct {
+	Declared             *DeclaredLanguages
+	Directories          []LocalizationDirectory
+	DeclaredWithoutFiles []string
+	Undeclared           []LocalizationDirectory
+	MissingTables        map[string][]string // path of the .lproj directory -> missing tables
+}
+
+func (r *LanguageReport) HasIssues() bool {
+	return len(r.DeclaredWithoutFiles) > 0 || len(r.Undeclared) > 0 || len(r.MissingTables) > 0
+}
+
+// FindKnownRegionsInPbxProj reads the knownRegions of an Xcode project.pbxproj file
+func FindKnownRegionsInPbxProj(pbxprojPath string) ([]string, error) {
+	content, err := os.ReadFile(pbxprojPath)
+	if err != nil {
+		return nil, err
+	}
+
+	match := knownRegionsRegex.FindSubmatch(content)
+	if match == nil {
+		return nil, fmt.Errorf("knownRegions not found in %s", pbxprojPath)
+	}
+
+	var regions []string
+	for _, region := range strings.Split(string(match[1]), ",") {
+		region = strings.Trim(strings.TrimSpace(region), "\"")
+		if region != "" {
+			regions = append(regions, region)
+		}
+	}
+
+	return regions, nil
+}
+
+// ReadStringCatalogLanguages reads the source language and all languages with localizations of a .xcstrings file
+func ReadStringCatalogLanguages(catalogPath string) (string, []string, error) {
+	content, err := os.ReadFile(catalogPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var catalog struct {
+		SourceLanguage string `json:"sourceLanguage"`
+		Strings        map[string]struct {
+			Localizations map[string]json.RawMessage `json:"localizations"`
+		} `json:"strings"`
+	}
+	if err := json.Unmarshal(content, &catalog); err != nil {
+		return "", nil, fmt.Errorf("error parsing String Catalog %s: %w", catalogPath, err)
+	}
+
+	languages := map[string]struct{}{}
+	if catalog.SourceLanguage != "" {
+		languages[catalog.SourceLanguage] = struct{}{}
+	}
+	for _, entry := range catalog.Strings {
+		for language := range entry.Localizations {
+			languages[language] = struct{}{}
+		}
+	}
+
+	result := MapToSlice(languages)
+	sort.Strings(result)
+	return catalog.SourceLanguage, result, nil
+}
+
+// FindDeclaredLanguages collects the languages declared by the Xcode project above startDir
+// and by all String Catalogs below root.
+func FindDeclaredLanguages(startDir string, root string, ignorePatterns []string) (*DeclaredLanguages, error) {
+	declared := &DeclaredLanguages{}
+	languages := map[string]struct{}{}
+
+	project, err := FindProject(startDir)
+	if err != nil && !errors.Is(err, ErrProjectNotFound) {
+		return nil, err
+	}
+	if project != nil && project.Kind == ProjectKindXcode {
+		regions, err := FindKnownRegionsInPbxProj(filepath.Join(project.Path, "project.pbxproj"))
+		if err != nil {
+			return nil, err
+		}
+		for _, region := range regions {
+			languages[region] = struct{}{}
+		}
+		languages[project.DevelopmentRegion] = struct{}{}
+		declared.DevelopmentRegion = project.DevelopmentRegion
+		declared.Sources = append(declared.Sources, project.Path)
+	}
+
+	catalogs, err := findFilesWithExtension(root, ".xcstrings", ignorePatterns)
+	if err != nil {
+		return nil, err
+	}
+	for _, catalog := range catalogs {
+		sourceLanguage, catalogLanguages, err := ReadStringCatalogLanguages(catalog)
+		if err != nil {
+			return nil, err
+		}
+		for _, language := range catalogLanguages {
+			languages[language] = struct{}{}
+		}
+		if declared.DevelopmentRegion == "" {
+			declared.DevelopmentRegion = sourceLanguage
+		}
+		declared.Sources = append(declared.Sources, catalog)
+	}
+
+	if len(declared.Sources) == 0 {
+		return nil, ErrNoDeclaredLanguages
+	}
+
+	declared.Languages = MapToSlice(languages)
+	sort.Strings(declared.Languages)
+	return declared, nil
+}
+
+// FindLocalizationDirectories finds all .lproj directories below root
+func FindLocalizationDirectories(root string, ignorePatterns []string) ([]LocalizationDirectory, error) {
+	var directories []LocalizationDirectory
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && isIgnored(d.Name(), ignorePatterns) {
+			return filepath.SkipDir
+		}
+		if !strings.HasSuffix(d.Name(), ".lproj") {
+			return nil
+		}
+
+		directory := LocalizationDirectory{
+			Language: strings.TrimSuffix(d.Name(), ".lproj"),
+			Path:     path,
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			if !entry.IsDir() && (ext == ".strings" || ext == ".stringsdict") {
+				directory.Tables = append(directory.Tables, strings.TrimSuffix(entry.Name(), ext))
+			}
+		}
+		directory.Tables = uniqueSorted(directory.Tables)
+		directories = append(directories, directory)
+
+		return filepath.SkipDir
+	})
+
+	return directories, err
+}
+
+// CompareLanguages compares the declared languages with the .lproj directories on disk.
+// Tables are compared with the development region directory next to each .lproj directory.
+func CompareLanguages(declared *DeclaredLanguages, directories []LocalizationDirectory) *LanguageReport {
+	report := &LanguageReport{
+		Declared:      declared,
+		Directories:   directories,
+		MissingTables: map[string][]string{},
+	}
+
+	declaredSet := SliceToMap(declared.Languages)
+	languagesOnDisk := map[string]struct{}{}
+	for _, directory := range directories {
+		languagesOnDisk[directory.Language] = struct{}{}
+		if _, ok := declaredSet[directory.Language]; !ok {
+			report.Undeclared = append(report.Undeclared, directory)
+		}
+	}
+
+	for _, language := range declared.Languages {
+		// Base.lproj only exists for projects with Interface Builder files
+		if language == BaseLanguage {
+			continue
+		}
+		if _, ok := languagesOnDisk[language]; !ok {
+			report.DeclaredWithoutFiles = append(report.DeclaredWithoutFiles, language)
+		}
+	}
+
+	// group the directories by the resource directory they are located in
+	groups := map[string][]LocalizationDirectory{}
+	for _, directory := range directories {
+		parent := filepath.Dir(directory.Path)
+		groups[parent] = append(groups[parent], directory)
+	}
+
+	for _, group := range groups {
+		referenceTables := referenceTablesForGroup(group, declared.DevelopmentRegion)
+		for _, directory := range group {
+			if directory.Language == BaseLanguage {
+				continue
+			}
+			tables := SliceToMap(directory.Tables)
+			for _, table := range referenceTables {
+				if _, ok := tables[table]; !ok {
+					report.MissingTables[directory.Path] = append(report.MissingTables[directory.Path], table)
+				}
+			}
+		}
+	}
+
+	return report
+}
+
+// referenceTablesForGroup returns the tables of the development region directory,
+// or the tables of all directories if the group has no development region directory.
+func referenceTablesForGroup(group []LocalizationDirectory, developmentRegion string) []string {
+	for _, directory := range group {
+		if directory.Language == developmentRegion {
+			return directory.Tables
+		}
+	}
+
+	var tables []string
+	for _, directory := range group {
+		if directory.Language != BaseLanguage {
+			tables = append(tables, directory.Tables...)
+		}
+	}
+	return uniqueSorted(tables)
+}
+
+func findFilesWithExtension(root string, ext string, ignorePatterns []string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != root && isIgnored(d.Name(), ignorePatterns) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() && filepath.Ext(path) == ext {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+func isIgnored(name string, ignorePatterns []string) bool {
+	for _, pattern := range ignorePatterns {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueSorted(values []string) []string {
+	result := MapToSlice(SliceToMap(values))
+	sort.Strings(result)
+	return result
+}
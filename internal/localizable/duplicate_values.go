@@ -0,0 +1,87 @@
+package localizable
+
+import (
+	"sort"
+)
+
+// DuplicateValue is a base value which is defined under several keys of a table
+type DuplicateValue struct {
+	Value string
+	Keys  []string // in file order
+
+	// DivergingLocales are the translations in which the keys do not share the same value.
+	// Folding such keys into one key loses a translation, e.g. if the value is translated depending on its context.
+	// Keys which are not translated in a locale are ignored.
+	DivergingLocales []string
+}
+
+// DuplicateValues returns the values of the base file which are defined under more than one key,
+// sorted by the number of keys, most keys first, and by value. Empty values are skipped.
+// If a key is defined more than once, its last definition is used just like at runtime.
+func (t *Table) DuplicateValues() []DuplicateValue {
+	base := t.Base()
+	if base == nil {
+		return nil
+	}
+
+	entries := base.Entries()
+	var values []string
+	keysByValue := make(map[string][]string)
+	for _, key := range uniqueKeysInFileOrder(base.File) {
+		value := entries[key].RawValue()
+		if value == "" {
+			continue
+		}
+		if _, ok := keysByValue[value]; !ok {
+			values = append(values, value)
+		}
+		keysByValue[value] = append(keysByValue[value], key)
+	}
+
+	var duplicates []DuplicateValue
+	for _, value := range values {
+		if keys := keysByValue[value]; len(keys) > 1 {
+			duplicates = append(duplicates, DuplicateValue{Value: value, Keys: keys})
+		}
+	}
+
+	for _, translation := range t.Translations() {
+		entries := translation.Entries()
+		for i := range duplicates {
+			if diverges(entries, duplicates[i].Keys) {
+				duplicates[i].DivergingLocales = append(duplicates[i].DivergingLocales, translation.Code)
+			}
+		}
+	}
+
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		if len(duplicates[i].Keys) != len(duplicates[j].Keys) {
+			return len(duplicates[i].Keys) > len(duplicates[j].Keys)
+		}
+		return duplicates[i].Value < duplicates[j].Value
+	})
+	return duplicates
+}
+
+// diverges reports whether the keys have different values in the entries of a translation
+func diverges(entries map[string]*Line, keys []string) bool {
+	values := make(map[string]bool)
+	for _, key := range keys {
+		if entry, ok := entries[key]; ok {
+			values[entry.RawValue()] = true
+		}
+	}
+	return len(values) > 1
+}
+
+func uniqueKeysInFileOrder(sf *StringsFile) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, line := range sf.Lines {
+		if line.Key != "" && !seen[line.Key] {
+			seen[line.Key] = true
+			keys = append(keys, line.Key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,74 @@
+package localizable
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// FormatSpecifier is a printf-style placeholder like %@, %d, %lld or %1$@ in a value
+type FormatSpecifier struct {
+	Text     string // specifier as written, e.g. "%1$@"
+	Argument int    // 1-based index of the argument the specifier refers to
+	Position bool   // whether the argument is given explicitly, e.g. %2$d
+	Type     string // argument type the specifier expects, e.g. "object" or "int"
+}
+
+// matches %[position$][flags][width][.precision][length]conversion. The space flag is left out,
+// because "50% off" would otherwise be read as the specifier `% o`.
+var formatSpecifierRegex = regexp.MustCompile(`%(?:(\d+)\$)?[-+0#']*(?:\d+|\*)?(?:\.(?:\d+|\*))?(hh|h|ll|l|L|q|j|z|t)?([@dDiuUxXoOfFeEgGaAcCsSp%])`)
+
+// ParseFormatSpecifiers returns the format specifiers of a value in order of appearance. `%%` is not a specifier.
+func ParseFormatSpecifiers(value string) []FormatSpecifier {
+	var specifiers []FormatSpecifier
+	nextArgument := 1
+
+	for _, match := range formatSpecifierRegex.FindAllStringSubmatch(value, -1) {
+		conversion := match[3]
+		if conversion == "%" {
+			continue
+		}
+
+		specifier := FormatSpecifier{
+			Text: match[0],
+			Type: specifierType(match[2], conversion),
+		}
+		if match[1] != "" {
+			specifier.Argument, _ = strconv.Atoi(match[1])
+			specifier.Position = true
+		} else {
+			specifier.Argument = nextArgument
+			nextArgument++
+		}
+		specifiers = append(specifiers, specifier)
+	}
+
+	return specifiers
+}
+
+// specifierType returns the argument type expected by a conversion. Specifiers of the same type are interchangeable,
+// e.g. %d and %i, or %ld and %lld which both expect a 64-bit integer on Apple platforms.
+func specifierType(length string, conversion string) string {
+	switch conversion {
+	case "@":
+		return "object"
+	case "d", "i", "u", "x", "X", "o":
+		switch length {
+		case "l", "ll", "q", "j", "z", "t":
+			return "long"
+		default:
+			return "int"
+		}
+	case "D", "U", "O":
+		return "long"
+	case "f", "F", "e", "E", "g", "G", "a", "A":
+		return "double"
+	case "c", "C":
+		return "char"
+	case "s", "S":
+		return "cstring"
+	case "p":
+		return "pointer"
+	default:
+		return conversion
+	}
+}
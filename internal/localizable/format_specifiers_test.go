@@ -0,0 +1,34 @@
+package localizable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFormatSpecifiers(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"Hello %@", []string{"%@"}},
+		{"%1$@ has %2$lld items", []string{"%1$@", "%2$lld"}},
+		{"%.2f of %d", []string{"%.2f", "%d"}},
+		{"100%% done", nil},
+		{"50% off", nil},
+		{"100 %", nil},
+		{"Spare 20 % now", nil},
+		{"%-5d%+d", []string{"%-5d", "%+d"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			var got []string
+			for _, specifier := range ParseFormatSpecifiers(test.value) {
+				got = append(got, specifier.Text)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
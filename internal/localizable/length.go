@@ -0,0 +1,116 @@
+package localizable
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+)
+
+// maxLengthRegex matches comments like /* max-length: 20 */ which limit the length of the following entry
+var maxLengthRegex = regexp.MustCompile(`^\s*(?:/\*|//)\s*max-length\s*:\s*(\d+)\s*(?:\*/)?\s*$`)
+
+// MaxLengths returns the limits declared with /* max-length: N */ comments directly above the entries by key
+func (sf *StringsFile) MaxLengths() map[string]int {
+	limits := make(map[string]int)
+	limit := 0
+	for _, line := range sf.Lines {
+		switch {
+		case line.Key != "":
+			if limit > 0 {
+				limits[line.Key] = limit
+			}
+			limit = 0
+		case strings.TrimSpace(line.Text) == "":
+			limit = 0 // the comment is detached from the entry
+		default:
+			if match := maxLengthRegex.FindStringSubmatch(line.Text); match != nil {
+				limit, _ = strconv.Atoi(match[1])
+			}
+		}
+	}
+	return limits
+}
+
+// Length returns the number of characters of the value as users see them on screen
+func (l Line) Length() int {
+	return GraphemeCount(Unescape(l.RawValue()))
+}
+
+// Unescape resolves the escape sequences of a .strings value like \n, \" and \U00E9
+func Unescape(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+
+	var sb strings.Builder
+	runes := []rune(value)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '\\' || i+1 == len(runes) {
+			sb.WriteRune(runes[i])
+			continue
+		}
+
+		i++
+		switch runes[i] {
+		case 'n':
+			sb.WriteRune('\n')
+		case 't':
+			sb.WriteRune('\t')
+		case 'r':
+			sb.WriteRune('\r')
+		case 'U', 'u':
+			if i+4 < len(runes) {
+				if code, err := strconv.ParseUint(string(runes[i+1:i+5]), 16, 32); err == nil {
+					sb.WriteRune(rune(code))
+					i += 4
+					continue
+				}
+			}
+			sb.WriteRune(runes[i])
+		default:
+			sb.WriteRune(runes[i])
+		}
+	}
+	return sb.String()
+}
+
+// GraphemeCount returns the number of grapheme clusters of s according to Unicode Standard Annex #29,
+// so that "é" written as e and a combining accent, flags and emoji sequences like 👩‍👩‍👧 count as a single character.
+func GraphemeCount(s string) int {
+	count := 0
+	joinsNext := false
+	graphemes := uniseg.NewGraphemes(s)
+	for graphemes.Next() {
+		runes := graphemes.Runes()
+		if !joinsNext || !isConjunctConsonant(runes[0]) {
+			count++
+		}
+		joinsNext = endsWithConjunctLinker(runes)
+	}
+	return count
+}
+
+// Rule GB9c of Unicode 15.1, which uniseg does not implement yet, keeps Indic conjuncts like क्ष together:
+// a consonant following a virama of Devanagari, Bengali, Gujarati, Oriya, Telugu or Malayalam does not start a new character.
+var conjunctLinkers = map[rune]bool{'\u094D': true, '\u09CD': true, '\u0ACD': true, '\u0B4D': true, '\u0C4D': true, '\u0D4D': true}
+
+var conjunctScripts = []*unicode.RangeTable{unicode.Devanagari, unicode.Bengali, unicode.Gujarati, unicode.Oriya, unicode.Telugu, unicode.Malayalam}
+
+func endsWithConjunctLinker(runes []rune) bool {
+	for i := len(runes) - 1; i > 0; i-- {
+		switch {
+		case conjunctLinkers[runes[i]]:
+			return true
+		case runes[i] != '\u200D' && !unicode.Is(unicode.Mn, runes[i]):
+			return false
+		}
+	}
+	return false
+}
+
+func isConjunctConsonant(r rune) bool {
+	return unicode.Is(unicode.Lo, r) && unicode.In(r, conjunctScripts...)
+}
@@ -0,0 +1,28 @@
+package localizable
+
+import "testing"
+
+func TestGraphemeCount(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{"Settings", 8},
+		{"é", 1},
+		{"👩‍👩‍👧", 1},
+		{"🇩🇪🇫🇷", 2},
+		{"👍🏽", 1},
+		{"क्ष", 1},
+		{"नमस्ते", 3},
+		{"한국어", 3},
+		{"\r\n", 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			if got := GraphemeCount(test.value); got != test.want {
+				t.Errorf("got %d, want %d", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package localizable
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BaseLocale is the locale of Base.lproj which is used as fallback base of a table
+const BaseLocale = "Base"
+
+// LocalizationSet groups strings files into tables (Localizable, InfoPlist, ...)
+// and the locales of each table, e.g. Table("Localizable").Locale("de").Entry("key").
+type LocalizationSet struct {
+	Tables []*Table
+}
+
+// Table is a strings table like Localizable.strings with one file per locale.
+// Tables with the same name in different resource directories are separate tables.
+type Table struct {
+	Name       string
+	Dir        string // directory containing the .lproj directories of the table
+	BaseLocale string
+	Locales    []*Locale // sorted by locale code
+	base       *Locale
+}
+
+// Locale is the file of a table for a single locale
+type Locale struct {
+	Code string // empty for files which are not located in a .lproj directory
+	File *StringsFile
+}
+
+// NewLocalizationSet groups the files into tables and designates the given base locale for each table.
+// Tables without a file for the base locale fall back to Base.lproj.
+func NewLocalizationSet(files []*StringsFile, baseLocale string) *LocalizationSet {
+	set := &LocalizationSet{}
+	tables := make(map[string]*Table)
+
+	for _, file := range files {
+		dir := filepath.Dir(file.Path)
+		if file.Locale() != "" {
+			dir = filepath.Dir(dir)
+		}
+
+		id := filepath.Join(dir, file.Table())
+		table, ok := tables[id]
+		if !ok {
+			table = &Table{Name: file.Table(), Dir: dir}
+			tables[id] = table
+			set.Tables = append(set.Tables, table)
+		}
+
+		// keep the first file if a locale is listed more than once
+		if table.Locale(file.Locale()) == nil {
+			table.Locales = append(table.Locales, &Locale{Code: file.Locale(), File: file})
+		}
+	}
+
+	for _, table := range set.Tables {
+		sort.Slice(table.Locales, func(i, j int) bool {
+			return table.Locales[i].Code < table.Locales[j].Code
+		})
+		table.designateBase(baseLocale)
+	}
+
+	sort.Slice(set.Tables, func(i, j int) bool {
+		if set.Tables[i].Dir != set.Tables[j].Dir {
+			return set.Tables[i].Dir < set.Tables[j].Dir
+		}
+		return set.Tables[i].Name < set.Tables[j].Name
+	})
+
+	return set
+}
+
+// NewLocalizationSetWithBase groups the files like NewLocalizationSet and designates the given file as base of its table.
+// Files which are not located in a .lproj directory and share the table name of the base are translations of the base,
+// e.g. Localizable_2.strings next to Localizable.strings. Other tables like InfoPlist.strings stay separate.
+func NewLocalizationSetWithBase(files []*StringsFile, base *StringsFile) *LocalizationSet {
+	var grouped []*StringsFile
+	var flat []*StringsFile
+	for _, file := range files {
+		if file.Locale() == "" && filepath.Clean(file.Path) != filepath.Clean(base.Path) && sameTableStem(file.Table(), base.Table()) {
+			flat = append(flat, file)
+		} else {
+			grouped = append(grouped, file)
+		}
+	}
+
+	set := NewLocalizationSet(grouped, base.Locale())
+	set.SetBase(base)
+	table := set.TableForFile(base)
+	for _, file := range flat {
+		table.Locales = append(table.Locales, &Locale{File: file})
+	}
+	return set
+}
+
+// sameTableStem reports whether the table name is the base table name, optionally followed by a suffix
+// like _2, -de or .de
+func sameTableStem(name string, baseName string) bool {
+	if !strings.HasPrefix(name, baseName) {
+		return false
+	}
+	suffix := name[len(baseName):]
+	return suffix == "" || strings.ContainsAny(suffix[:1], "_-.")
+}
+
+// Table returns the first table with the given name or nil if there is none
+func (s *LocalizationSet) Table(name string) *Table {
+	if s == nil {
+		return nil
+	}
+	for _, table := range s.Tables {
+		if table.Name == name {
+			return table
+		}
+	}
+	return nil
+}
+
+// TableForFile returns the table the given file belongs to
+func (s *LocalizationSet) TableForFile(file *StringsFile) *Table {
+	for _, table := range s.Tables {
+		for _, locale := range table.Locales {
+			if filepath.Clean(locale.File.Path) == filepath.Clean(file.Path) {
+				return table
+			}
+		}
+	}
+	return nil
+}
+
+// SetBase designates the locale of the given file as base of its table
+func (s *LocalizationSet) SetBase(file *StringsFile) {
+	table := s.TableForFile(file)
+	if table == nil {
+		return
+	}
+	table.BaseLocale = file.Locale()
+	for _, locale := range table.Locales {
+		if filepath.Clean(locale.File.Path) == filepath.Clean(file.Path) {
+			table.base = locale
+		}
+	}
+}
+
+// BaseFiles returns the base file of each table which has one
+func (s *LocalizationSet) BaseFiles() []*StringsFile {
+	var files []*StringsFile
+	for _, table := range s.Tables {
+		if base := table.Base(); base != nil {
+			files = append(files, base.File)
+		}
+	}
+	return files
+}
+
+func (t *Table) designateBase(baseLocale string) {
+	switch {
+	case t.Locale(baseLocale) != nil:
+		t.BaseLocale = baseLocale
+	case t.Locale(BaseLocale) != nil:
+		t.BaseLocale = BaseLocale
+	default:
+		t.BaseLocale = ""
+	}
+	t.base = t.Locale(t.BaseLocale)
+}
+
+// Locale returns the file of the table for the given locale or nil if there is none
+func (t *Table) Locale(code string) *Locale {
+	if t == nil {
+		return nil
+	}
+	for _, locale := range t.Locales {
+		if locale.Code == code {
+			return locale
+		}
+	}
+	return nil
+}
+
+// Base returns the base locale of the table or nil if the table has no base.
+// Files which are not located in a .lproj directory are the base of their own table.
+func (t *Table) Base() *Locale {
+	if t == nil {
+		return nil
+	}
+	return t.base
+}
+
+// Translations returns all locales of the table except the base locale and Base.lproj
+func (t *Table) Translations() []*Locale {
+	var translations []*Locale
+	for _, locale := range t.Locales {
+		if locale != t.base && locale.Code != BaseLocale {
+			translations = append(translations, locale)
+		}
+	}
+	return translations
+}
+
+// Orphans returns the lines of a translation whose key is not defined in the base locale,
+// e.g. left behind after a base key was renamed or deleted. It returns nil if the table has no base.
+func (t *Table) Orphans(translation *Locale) []Line {
+	base := t.Base()
+	if base == nil {
+		return nil
+	}
+
+	baseEntries := base.Entries()
+	var orphans []Line
+	for _, line := range translation.File.Lines {
+		if _, ok := baseEntries[line.Key]; line.Key != "" && !ok {
+			orphans = append(orphans, line)
+		}
+	}
+	return orphans
+}
+
+// Entry returns the line of the given key. If a key is defined more than once,
+// the last definition wins, just like at runtime. It returns nil if the key is not defined.
+func (l *Locale) Entry(key string) *Line {
+	if l == nil {
+		return nil
+	}
+	lines := l.File.GetLinesForKey(key)
+	if len(lines) == 0 {
+		return nil
+	}
+	return &lines[len(lines)-1]
+}
+
+// Entries returns the entry of each key, the last definition wins just like at runtime.
+// Use it instead of Entry to look up many keys.
+func (l *Locale) Entries() map[string]*Line {
+	entries := make(map[string]*Line)
+	if l == nil {
+		return entries
+	}
+	for i := range l.File.Lines {
+		if line := &l.File.Lines[i]; line.Key != "" {
+			entries[line.Key] = line
+		}
+	}
+	return entries
+}
+
+// Keys returns the sorted unique keys of the locale
+func (l *Locale) Keys() []string {
+	if l == nil {
+		return nil
+	}
+	keys := l.File.GetAllKeys()
+	sort.Strings(keys)
+	return keys
+}
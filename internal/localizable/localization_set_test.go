@@ -0,0 +1,40 @@
+package localizable
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func newTestFile(t *testing.T, path string, content string) *StringsFile {
+	t.Helper()
+	file, err := ParseStringsFile(path, []byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestNewLocalizationSetWithBase(t *testing.T) {
+	base := newTestFile(t, filepath.Join("App", "Localizable.strings"), `"title" = "Title";`)
+	translation := newTestFile(t, filepath.Join("App", "Localizable_2.strings"), `"title" = "Titel";`)
+	infoPlist := newTestFile(t, filepath.Join("App", "InfoPlist.strings"), `"NSCameraUsageDescription" = "Camera";`)
+
+	set := NewLocalizationSetWithBase([]*StringsFile{base, translation, infoPlist}, base)
+
+	table := set.TableForFile(base)
+	if table.Base() == nil || table.Base().File != base {
+		t.Fatalf("expected %s to be the base of its table", base.Path)
+	}
+	translations := table.Translations()
+	if len(translations) != 1 || translations[0].File != translation {
+		t.Errorf("expected %s to be the only translation, got %d translations", translation.Path, len(translations))
+	}
+
+	infoPlistTable := set.TableForFile(infoPlist)
+	if infoPlistTable == table {
+		t.Fatalf("expected %s to be a separate table", infoPlist.Path)
+	}
+	if len(infoPlistTable.Translations()) != 0 {
+		t.Errorf("expected %s to have no translations", infoPlist.Path)
+	}
+}
@@ -0,0 +1,75 @@
+package localizable
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Markup is the HTML, Markdown and inflection markup of a value, which SwiftUI renders with AttributedString
+type Markup struct {
+	Tags            []string       // names of the opening HTML tags in order, e.g. "b" or "a"
+	UnbalancedTags  []string       // names of HTML tags which are not closed or not opened
+	Links           []string       // URLs of HTML and Markdown links in order
+	Inflections     int            // number of ^[...](inflect: true) attributes
+	EmphasisMarkers map[string]int // number of Markdown emphasis markers like ** or `
+}
+
+var (
+	htmlTagRegex      = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)\b([^<>]*?)(/?)>`)
+	htmlHrefRegex     = regexp.MustCompile(`\bhref\s*=\s*\\?["']([^"'\\]*)\\?["']`) // quotes may be escaped in .strings values
+	inflectionRegex   = regexp.MustCompile(`\^\[[^\]]*\]\(\s*inflect\s*:[^)]*\)`)
+	markdownLinkRegex = regexp.MustCompile(`\[[^\]]*\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+)
+
+// markdownEmphasisMarkers are counted in this order, so that ** is not counted as two *
+var markdownEmphasisMarkers = []string{"**", "__", "~~", "`"}
+
+// voidTags have no closing tag
+var voidTags = map[string]bool{"br": true, "img": true, "hr": true}
+
+// ParseMarkup parses the markup of a value
+func ParseMarkup(value string) Markup {
+	markup := Markup{EmphasisMarkers: map[string]int{}}
+
+	// inflection attributes look like Markdown links, so they are removed first
+	markup.Inflections = len(inflectionRegex.FindAllString(value, -1))
+	value = inflectionRegex.ReplaceAllString(value, "")
+
+	var open []string
+	for _, match := range htmlTagRegex.FindAllStringSubmatch(value, -1) {
+		closing, name, attributes, selfClosing := match[1] == "/", strings.ToLower(match[2]), match[3], match[4] == "/"
+
+		switch {
+		case closing:
+			if len(open) > 0 && open[len(open)-1] == name {
+				open = open[:len(open)-1]
+			} else {
+				markup.UnbalancedTags = append(markup.UnbalancedTags, name)
+			}
+		default:
+			markup.Tags = append(markup.Tags, name)
+			if href := htmlHrefRegex.FindStringSubmatch(attributes); href != nil {
+				markup.Links = append(markup.Links, href[1])
+			}
+			if !selfClosing && !voidTags[name] {
+				open = append(open, name)
+			}
+		}
+	}
+	markup.UnbalancedTags = append(markup.UnbalancedTags, open...)
+
+	for _, match := range markdownLinkRegex.FindAllStringSubmatch(value, -1) {
+		markup.Links = append(markup.Links, match[1])
+	}
+
+	// URLs may contain markers like __ which are no emphasis
+	value = markdownLinkRegex.ReplaceAllString(value, "")
+	for _, marker := range markdownEmphasisMarkers {
+		if count := strings.Count(value, marker); count > 0 {
+			markup.EmphasisMarkers[marker] = count
+			value = strings.ReplaceAll(value, marker, "")
+		}
+	}
+
+	return markup
+}
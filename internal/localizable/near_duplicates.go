@@ -0,0 +1,131 @@
+package localizable
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Ways in which near-duplicate keys differ
+const (
+	DifferenceNormalization = "Unicode normalization"
+	DifferenceWhitespace    = "whitespace"
+	DifferenceCase          = "case"
+	DifferenceLookalike     = "look-alike characters"
+)
+
+// keyNormalizations are applied in this order to compute the skeleton of a key
+var keyNormalizations = []struct {
+	difference string
+	normalize  func(string) string
+}{
+	{DifferenceNormalization, norm.NFC.String},
+	{DifferenceWhitespace, removeWhitespace},
+	{DifferenceCase, strings.ToLower},
+	{DifferenceLookalike, replaceLookalikes},
+}
+
+// lookalikes maps characters to the ASCII character they are easily confused with
+var lookalikes = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'в': 'b', 'е': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o', 'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x',
+	'і': 'i', 'ј': 'j', 'ѕ': 's', 'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w',
+	// Greek
+	'α': 'a', 'β': 'b', 'ε': 'e', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x',
+	// dashes and dots
+	'‐': '-', '‑': '-', '‒': '-', '–': '-', '—': '-', '−': '-', '․': '.', '·': '.',
+	// dotless i. Digits are not mapped to letters, since keys like `top_10` and `version_1` are not look-alikes.
+	'ı': 'i',
+}
+
+// KeySkeleton returns the key without differences of Unicode normalization, whitespace, case and look-alike characters
+func KeySkeleton(key string) string {
+	for _, normalization := range keyNormalizations {
+		key = normalization.normalize(key)
+	}
+	return key
+}
+
+// KeyDifferences returns how two near-duplicate keys differ, or nil if their skeletons are not equal.
+// A difference is only listed if the keys are not equal without ignoring it.
+func KeyDifferences(a string, b string) []string {
+	if a == b || KeySkeleton(a) != KeySkeleton(b) {
+		return nil
+	}
+
+	var differences []string
+	// the compatibility normalization of look-alike characters also composes characters, so it is left out here
+	isNormalized := norm.NFC.String(a) == a && norm.NFC.String(b) == b
+	if !isNormalized && mapLookalikes(strings.ToLower(removeWhitespace(a))) != mapLookalikes(strings.ToLower(removeWhitespace(b))) {
+		differences = append(differences, DifferenceNormalization)
+	}
+
+	for i, ignored := range keyNormalizations[1:] {
+		x, y := a, b
+		for j, normalization := range keyNormalizations {
+			if j != i+1 {
+				x, y = normalization.normalize(x), normalization.normalize(y)
+			}
+		}
+		if x != y {
+			differences = append(differences, ignored.difference)
+		}
+	}
+	if len(differences) == 0 {
+		// several normalizations remove the same difference, like a full-width space
+		differences = append(differences, DifferenceLookalike)
+	}
+	return differences
+}
+
+// FindNearDuplicateKeys returns groups of different keys which have the same skeleton, by skeleton.
+// Keys are compared as written between the quotes, because the parsed keys are trimmed.
+// The lines of each group are in file order, keys defined more than once are listed once.
+func (sf *StringsFile) FindNearDuplicateKeys() map[string][]Line {
+	groups := make(map[string][]Line)
+	seen := make(map[string]bool)
+	for _, line := range sf.Lines {
+		if line.Key == "" || seen[line.RawKey()] {
+			continue
+		}
+		seen[line.RawKey()] = true
+		skeleton := KeySkeleton(line.RawKey())
+		groups[skeleton] = append(groups[skeleton], line)
+	}
+
+	for skeleton, lines := range groups {
+		if len(lines) < 2 {
+			delete(groups, skeleton)
+		}
+	}
+	return groups
+}
+
+func removeWhitespace(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// replaceLookalikes applies the compatibility normalization, which replaces full-width letters and ligatures,
+// and replaces look-alike characters
+func replaceLookalikes(s string) string {
+	return mapLookalikes(norm.NFKC.String(s))
+}
+
+// mapLookalikes removes invisible characters and replaces characters which look like ASCII characters
+func mapLookalikes(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Cf, r) {
+			return -1
+		}
+		if replacement, ok := lookalikes[r]; ok {
+			return replacement
+		}
+		return r
+	}, s)
+}
@@ -63,6 +63,11 @@ func (m *StringsFileManager) GetFile(path string) *StringsFile {
 	return nil
 }
 
+// LocalizationSet groups the files into tables and locales with the given base locale
+func (m *StringsFileManager) LocalizationSet(baseLocale string) *LocalizationSet {
+	return NewLocalizationSet(m.Files, baseLocale)
+}
+
 func (m *StringsFileManager) GetKeysForFile(file string) []string {
 	keys := make(map[string]struct{})
 	for _, f := range m.Files {
@@ -2,9 +2,15 @@ package localizable
 
 import (
 	"bufio"
+	"bytes"
+	"io"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/phillippbertram/xc-strings/internal/constants"
 )
 
 type Line struct {
@@ -18,6 +24,67 @@ func (l Line) IsKeyValue() bool {
 	return l.Key != "" // TODO: necessary= && strings.Contains(l.Text, "=")
 }
 
+// RawKey returns the key between the quotes as written in the file, including surrounding whitespace. Key is trimmed instead.
+func (l Line) RawKey() string {
+	before, _, ok := strings.Cut(l.Text, "=")
+	start := strings.Index(before, `"`)
+	end := strings.LastIndex(before, `"`)
+	if !ok || start < 0 || end <= start {
+		return l.Key
+	}
+	return before[start+1 : end]
+}
+
+// RawValue returns the value between the quotes as written in the file, including surrounding whitespace and
+// escape sequences like \n. Value is trimmed instead.
+func (l Line) RawValue() string {
+	_, after, ok := strings.Cut(l.Text, "=")
+	if !ok {
+		return l.Value
+	}
+	start := strings.Index(after, `"`)
+	if start < 0 {
+		return l.Value
+	}
+
+	after = after[start+1:]
+	for i := 0; i < len(after); i++ {
+		switch after[i] {
+		case '\\':
+			i++ // skip the escaped character
+		case '"':
+			return after[:i]
+		}
+	}
+	return after
+}
+
+// SetRawValue replaces the value between the quotes and keeps the rest of the line as it is
+func (l *Line) SetRawValue(value string) {
+	key, after, ok := strings.Cut(l.Text, "=")
+	start := strings.Index(after, `"`)
+	if !ok || start < 0 {
+		return
+	}
+
+	rest := after[start+1+len(l.RawValue()):]
+	*l = parseLine(key+"="+after[:start+1]+value+rest, l.LineNumber)
+}
+
+// Sanitized returns the text of the line as written by Sanitize
+func (l Line) Sanitized() string {
+	return sanitizeLine(&l)
+}
+
+// Column returns the 1-based column of the first non-whitespace character of the line, 0 for blank lines
+func (l Line) Column() int {
+	trimmed := strings.TrimLeft(l.Text, " \t")
+	if trimmed == "" {
+		return 0
+	}
+	return utf8.RuneCountInString(l.Text[:len(l.Text)-len(trimmed)]) + 1
+}
+
 type FileInfoSummary struct {
 	FilePath       string
 	TotalKeys      int
@@ -28,6 +95,8 @@ type FileInfoSummary struct {
 type StringsFile struct {
 	Path  string
 	Lines []Line
+
+	suppressions *suppressions // parsed on demand by IsSuppressed
 }
 
 // NewStringsFile creates a new StringsFile instance
@@ -41,6 +110,13 @@ func NewStringsFile(path string) (*StringsFile, error) {
 	return str, err
 }
 
+// ParseStringsFile parses the content of a strings file, like a version of the file from the git history
+func ParseStringsFile(path string, content []byte) (*StringsFile, error) {
+	str := &StringsFile{Path: path}
+	err := str.parseLines(bytes.NewReader(content))
+	return str, err
+}
+
 // parse reads the file and parses it into lines
 func (sf *StringsFile) parse() error {
 	file, err := os.Open(sf.Path)
@@ -49,32 +125,59 @@ func (sf *StringsFile) parse() error {
 	}
 	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
+	return sf.parseLines(file)
+}
+
+func (sf *StringsFile) parseLines(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
 	sf.Lines = nil // Reset lines
 	lineNumber := 1
 
 	for scanner.Scan() {
-		line := scanner.Text()
-		parsedLine := Line{
-			Text:       line,
-			LineNumber: lineNumber,
-		}
-
-		// Attempt to parse as key-value if possible
-		if parts := strings.SplitN(line, "=", 2); len(parts) == 2 {
-			key := strings.Trim(parts[0], " \"")
-			value := strings.Trim(parts[1], " \";")
-			parsedLine.Key = key
-			parsedLine.Value = value
-		}
-
-		sf.Lines = append(sf.Lines, parsedLine)
+		sf.Lines = append(sf.Lines, parseLine(scanner.Text(), lineNumber))
 		lineNumber++
 	}
 
 	return scanner.Err()
 }
 
+func parseLine(text string, lineNumber int) Line {
+	parsedLine := Line{
+		Text:       text,
+		LineNumber: lineNumber,
+	}
+
+	// Attempt to parse as key-value if possible
+	if parts := strings.SplitN(text, "=", 2); len(parts) == 2 {
+		key := strings.Trim(parts[0], " \"")
+		value := strings.Trim(parts[1], " \";")
+		parsedLine.Key = key
+		parsedLine.Value = value
+	}
+	return parsedLine
+}
+
+// Table returns the name of the strings table, e.g. "Localizable" for Localizable.strings
+func (sf *StringsFile) Table() string {
+	return strings.TrimSuffix(filepath.Base(sf.Path), filepath.Ext(sf.Path))
+}
+
+// Locale returns the locale derived from the enclosing .lproj directory, e.g. "de" for de.lproj.
+// It returns an empty string if the file is not located in a .lproj directory.
+func (sf *StringsFile) Locale() string {
+	dir := filepath.Base(filepath.Dir(sf.Path))
+	if !strings.HasSuffix(dir, ".lproj") {
+		return ""
+	}
+	return strings.TrimSuffix(dir, ".lproj")
+}
+
+// IsReferencedInCode reports whether the keys of the file are looked up in code.
+// Keys of InfoPlist.strings are read by the system instead.
+func (sf *StringsFile) IsReferencedInCode() bool {
+	return sf.Table() != constants.InfoPlistTable
+}
+
 func (sf *StringsFile) GetAllKeys() []string {
 	// only unique keys
 	keys := make(map[string]struct{})
@@ -165,27 +268,68 @@ func (sf *StringsFile) RemoveKey(key string) []Line {
 	return removedLines
 }
 
-func (sf *StringsFile) Sort() {
-	// Filter out empty lines and non-key-value lines
-	var keyLines []Line
+// RemoveLines removes the given lines, identified by their line number
+func (sf *StringsFile) RemoveLines(lines []Line) {
+	removed := make(map[int]bool, len(lines))
+	for _, line := range lines {
+		removed[line.LineNumber] = true
+	}
+
+	newLines := make([]Line, 0, len(sf.Lines))
 	for _, line := range sf.Lines {
-		if line.Key != "" && strings.TrimSpace(line.Text) != "" {
-			keyLines = append(keyLines, line)
+		if !removed[line.LineNumber] {
+			newLines = append(newLines, line)
+		}
+	}
+	sf.Lines = newLines
+}
+
+// Sort sorts the entries by key and groups them by prefix.
+// Comments directly above an entry, like suppression comments, move with the entry.
+// All other comments are kept at the top of the file.
+func (sf *StringsFile) Sort() {
+	type entry struct {
+		comments []Line
+		line     Line
+	}
+
+	var header []Line
+	var entries []entry
+	var comments []Line
+	for _, line := range sf.expandSuppressionBlocks() {
+		switch {
+		case line.Key != "":
+			entries = append(entries, entry{comments: comments, line: line})
+			comments = nil
+		case strings.TrimSpace(line.Text) == "":
+			// a blank line detaches the comments above it from the next entry
+			if len(comments) > 0 {
+				header = append(header, comments...)
+				header = append(header, Line{Text: ""})
+				comments = nil
+			}
+		default:
+			comments = append(comments, line)
 		}
 	}
+	header = append(header, comments...)
+	if len(header) > 0 && strings.TrimSpace(header[len(header)-1].Text) == "" {
+		header = header[:len(header)-1]
+	}
 
-	// Sort lines by key
-	sort.Slice(keyLines, func(i, j int) bool {
-		return keyLines[i].Key < keyLines[j].Key
+	// Sort entries by key
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].line.Key < entries[j].line.Key
 	})
 
 	// Group by prefix and insert empty lines
-	var sortedLines []Line
+	sortedLines := header
 	currentPrefix := ""
 
-	for i, line := range keyLines {
+	for i, entry := range entries {
+		line := entry.line
 		if i == 0 || !strings.HasPrefix(line.Key, currentPrefix) {
-			if i != 0 {
+			if i != 0 || len(header) > 0 {
 				// Add an empty line to separate groups
 				sortedLines = append(sortedLines, Line{Text: ""})
 			}
@@ -214,6 +358,7 @@ func (sf *StringsFile) Sort() {
 			}
 
 		}
+		sortedLines = append(sortedLines, entry.comments...)
 		sortedLines = append(sortedLines, line)
 	}
 
@@ -269,6 +414,33 @@ func (sf *StringsFile) IsSorted() bool {
 	return true // If all keys are in order or there are no keys, the file is sorted
 }
 
+// UnsortedLines returns the lines whose key is sorted before the key of the preceding key-value line
+func (sf *StringsFile) UnsortedLines() []Line {
+	var unsorted []Line
+	var lastKey string
+	for _, line := range sf.Lines {
+		if line.Key == "" {
+			continue
+		}
+		if lastKey != "" && lastKey > line.Key {
+			unsorted = append(unsorted, line)
+		}
+		lastKey = line.Key
+	}
+	return unsorted
+}
+
+// UnsanitizedLines returns the lines which would be changed by Sanitize
+func (sf *StringsFile) UnsanitizedLines() []Line {
+	var unsanitized []Line
+	for _, line := range sf.Lines {
+		if line.Sanitized() != line.Text {
+			unsanitized = append(unsanitized, line)
+		}
+	}
+	return unsanitized
+}
+
 func (sf *StringsFile) IsSanitized() bool {
 	for _, line := range sf.Lines {
 		sanitizedText := sanitizeLine(&line)
@@ -308,6 +480,15 @@ func sanitizeLine(line *Line) string {
 	return trimmedLine
 }
 
+// Content returns the text of the file as written by Save
+func (sf *StringsFile) Content() string {
+	var sb strings.Builder
+	for _, line := range sf.Lines {
+		sb.WriteString(line.Text + "\n")
+	}
+	return sb.String()
+}
+
 // Save writes the StringsFile back to the file
 func (sf *StringsFile) Save() error {
 	file, err := os.Create(sf.Path)
@@ -0,0 +1,174 @@
+package localizable
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Suppression comments disable rules of `xcs check` for single entries, ranges or whole files:
+//
+//	/* xcs-disable-next-line emptyValues */
+//	/* xcs-disable unused */ ... /* xcs-enable */
+//	/* xcs-disable-file duplicates */
+//
+// Without rule IDs all rules are disabled. Rule IDs are separated by spaces or commas.
+const (
+	suppressNextLine = "disable-next-line"
+	suppressFile     = "disable-file"
+	suppressBlock    = "disable"
+	enableBlock      = "enable"
+)
+
+// allRules is used as rule ID if a suppression comment does not list any rules
+const allRules = "*"
+
+var suppressionRegex = regexp.MustCompile(`^\s*(?:/\*|//)\s*xcs-(disable-next-line|disable-file|disable|enable)\b(.*?)\s*(?:\*/)?\s*$`)
+
+// parseSuppression returns the kind and the rule IDs of a suppression comment
+func parseSuppression(text string) (string, []string, bool) {
+	match := suppressionRegex.FindStringSubmatch(text)
+	if match == nil {
+		return "", nil, false
+	}
+
+	ruleIDs := strings.FieldsFunc(match[2], func(r rune) bool {
+		return r == ' ' || r == ',' || r == '\t'
+	})
+	if len(ruleIDs) == 0 {
+		ruleIDs = []string{allRules}
+	}
+	return match[1], ruleIDs, true
+}
+
+// suppressions are the rules disabled by the suppression comments of a file
+type suppressions struct {
+	lines  []Line                  // the lines the suppressions were parsed from
+	file   map[string]bool         // rules disabled for the whole file
+	byLine map[int]map[string]bool // rules disabled for single entries by line number
+}
+
+// IsSuppressed reports whether the rule is disabled by a suppression comment for the given line.
+// Line 0 refers to the whole file, which can only be suppressed with xcs-disable-file.
+func (sf *StringsFile) IsSuppressed(ruleID string, lineNumber int) bool {
+	s := sf.parseSuppressions()
+	matches := func(ruleIDs map[string]bool) bool {
+		return ruleIDs[allRules] || ruleIDs[ruleID]
+	}
+	return matches(s.file) || matches(s.byLine[lineNumber])
+}
+
+// parseSuppressions returns the suppressions of the file. They are parsed once and again whenever the lines are replaced,
+// e.g. after sorting or removing keys.
+func (sf *StringsFile) parseSuppressions() *suppressions {
+	if s := sf.suppressions; s != nil && len(s.lines) == len(sf.Lines) && (len(s.lines) == 0 || &s.lines[0] == &sf.Lines[0]) {
+		return s
+	}
+
+	s := &suppressions{lines: sf.Lines, file: map[string]bool{}, byLine: map[int]map[string]bool{}}
+	blockDisabled := map[string]bool{}
+	nextLineDisabled := map[string]bool{}
+
+	for _, line := range sf.Lines {
+		if line.Key != "" {
+			disabled := copyRuleIDs(blockDisabled)
+			for id := range nextLineDisabled {
+				disabled[id] = true
+			}
+			s.disable(line.LineNumber, disabled)
+			nextLineDisabled = map[string]bool{}
+			continue
+		}
+
+		// comments and blank lines are only covered by ranges
+		s.disable(line.LineNumber, blockDisabled)
+
+		kind, ruleIDs, ok := parseSuppression(line.Text)
+		if !ok {
+			continue
+		}
+		// the rules disabled before this comment stay untouched
+		blockDisabled = copyRuleIDs(blockDisabled)
+		for _, id := range ruleIDs {
+			switch kind {
+			case suppressFile:
+				s.file[id] = true
+			case suppressBlock:
+				blockDisabled[id] = true
+			case suppressNextLine:
+				nextLineDisabled[id] = true
+			case enableBlock:
+				if id == allRules {
+					blockDisabled = map[string]bool{}
+				} else {
+					delete(blockDisabled, id)
+				}
+			}
+		}
+	}
+
+	sf.suppressions = s
+	return s
+}
+
+// disable records the rules disabled for a line. Lines added by sorting have no line number,
+// and line 0 refers to the whole file, so they are skipped.
+func (s *suppressions) disable(lineNumber int, ruleIDs map[string]bool) {
+	if lineNumber > 0 && len(ruleIDs) > 0 {
+		s.byLine[lineNumber] = ruleIDs
+	}
+}
+
+func copyRuleIDs(ruleIDs map[string]bool) map[string]bool {
+	copied := make(map[string]bool, len(ruleIDs))
+	for id := range ruleIDs {
+		copied[id] = true
+	}
+	return copied
+}
+
+// expandSuppressionBlocks replaces xcs-disable ... xcs-enable ranges by an xcs-disable-next-line comment
+// for each entry of the range, so that the entries stay suppressed when they are reordered
+func (sf *StringsFile) expandSuppressionBlocks() []Line {
+	var lines []Line
+	disabled := map[string]bool{}
+
+	for _, line := range sf.Lines {
+		if line.Key != "" && len(disabled) > 0 {
+			lines = append(lines, Line{Text: nextLineSuppression(disabled)})
+		}
+
+		kind, ruleIDs, ok := parseSuppression(line.Text)
+		if !ok || line.Key != "" || (kind != suppressBlock && kind != enableBlock) {
+			lines = append(lines, line)
+			continue
+		}
+
+		for _, id := range ruleIDs {
+			switch {
+			case kind == suppressBlock:
+				disabled[id] = true
+			case id == allRules:
+				disabled = map[string]bool{}
+			default:
+				delete(disabled, id)
+			}
+		}
+	}
+
+	return lines
+}
+
+func nextLineSuppression(ruleIDs map[string]bool) string {
+	if ruleIDs[allRules] {
+		return fmt.Sprintf("/* xcs-%s */", suppressNextLine)
+	}
+
+	ids := make([]string, 0, len(ruleIDs))
+	for id := range ruleIDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return fmt.Sprintf("/* xcs-%s %s */", suppressNextLine, strings.Join(ids, " "))
+}
@@ -0,0 +1,58 @@
+package localizable
+
+import "testing"
+
+func TestIsSuppressed(t *testing.T) {
+	file := parseTestFile(t, `/* xcs-disable-file sorting */
+/* xcs-disable-next-line emptyValues */
+"a" = "";
+"b" = "";
+/* xcs-disable unused, emptyValues */
+"c" = "";
+/* xcs-enable emptyValues */
+"d" = "";
+/* xcs-enable */
+/* xcs-disable-next-line */
+"e" = "";
+"f" = "";`)
+
+	tests := []struct {
+		ruleID     string
+		lineNumber int
+		suppressed bool
+	}{
+		{"sorting", 0, true},
+		{"sorting", 4, true},
+		{"emptyValues", 3, true},
+		{"unused", 3, false},
+		{"emptyValues", 4, false},
+		{"emptyValues", 6, true},
+		{"unused", 6, true},
+		{"emptyValues", 8, false},
+		{"unused", 8, true},
+		{"unused", 11, true},
+		{"duplicates", 11, true},
+		{"unused", 12, false},
+	}
+
+	for _, test := range tests {
+		if suppressed := file.IsSuppressed(test.ruleID, test.lineNumber); suppressed != test.suppressed {
+			t.Errorf("IsSuppressed(%q, %d) = %v, want %v", test.ruleID, test.lineNumber, suppressed, test.suppressed)
+		}
+	}
+}
+
+func TestIsSuppressedAfterLinesChanged(t *testing.T) {
+	file := parseTestFile(t, `"a" = "";
+/* xcs-disable-next-line emptyValues */
+"b" = "";`)
+
+	if !file.IsSuppressed("emptyValues", 3) {
+		t.Fatal("expected line 3 to be suppressed")
+	}
+
+	file.Lines = file.Lines[:1]
+	if file.IsSuppressed("emptyValues", 3) {
+		t.Error("expected the suppressions to be parsed again after the lines were replaced")
+	}
+}
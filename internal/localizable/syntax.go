@@ -0,0 +1,325 @@
+package localizable
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// SyntaxError is a violation of the .strings syntax, which Xcode rejects at build time
+type SyntaxError struct {
+	Line    int // line number of the file
+	Column  int // 1-based column in characters
+	Message string
+
+	repairs []syntaxRepair
+}
+
+// syntaxRepair inserts text at a byte offset of a line
+type syntaxRepair struct {
+	lineIndex int
+	offset    int
+	text      string
+}
+
+// IsRepairable reports whether RepairSyntax can fix the error without guessing
+func (e SyntaxError) IsRepairable() bool {
+	return len(e.repairs) > 0
+}
+
+type tokenKind int
+
+const (
+	tokenString tokenKind = iota
+	tokenWord
+	tokenEquals
+	tokenSemicolon
+	tokenOther
+)
+
+type token struct {
+	kind         tokenKind
+	text         string
+	lineIndex    int
+	offset       int // byte offset of the token in the line
+	endLine      int // line index of the end of the token, strings may span several lines
+	end          int // byte offset after the token in the end line
+	unterminated bool
+}
+
+// matches lines starting with a key and `=`, which end a string whose closing quote is missing
+var entryStartRegex = regexp.MustCompile(`^\s*"(?:[^"\\]|\\.)*"\s*=`)
+
+// characters of unquoted words, which old-style property lists allow but Xcode does not for strings files
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("_.$:/+-", c) >= 0
+}
+
+// SyntaxErrors returns the syntax errors of the file sorted by position
+func (sf *StringsFile) SyntaxErrors() []SyntaxError {
+	tokens, errors := sf.tokenize()
+	errors = append(errors, sf.parseEntries(tokens)...)
+
+	sort.SliceStable(errors, func(i, j int) bool {
+		if errors[i].Line != errors[j].Line {
+			return errors[i].Line < errors[j].Line
+		}
+		return errors[i].Column < errors[j].Column
+	})
+	return errors
+}
+
+// HasMultilineStrings reports whether a key or value spans several lines.
+// Such entries are valid, but cannot be moved or changed line by line.
+func (sf *StringsFile) HasMultilineStrings() bool {
+	tokens, _ := sf.tokenize()
+	for _, t := range tokens {
+		if t.kind == tokenString && t.endLine != t.lineIndex {
+			return true
+		}
+	}
+	return false
+}
+
+// RepairSyntax fixes the repairable syntax errors for which repair returns true and returns their number
+func (sf *StringsFile) RepairSyntax(repair func(SyntaxError) bool) int {
+	var repairs []syntaxRepair
+	count := 0
+	for _, syntaxError := range sf.SyntaxErrors() {
+		if syntaxError.IsRepairable() && repair(syntaxError) {
+			repairs = append(repairs, syntaxError.repairs...)
+			count++
+		}
+	}
+
+	// insert from the end of each line, so that the offsets of the other insertions stay valid
+	sort.SliceStable(repairs, func(i, j int) bool {
+		if repairs[i].lineIndex != repairs[j].lineIndex {
+			return repairs[i].lineIndex < repairs[j].lineIndex
+		}
+		return repairs[i].offset > repairs[j].offset
+	})
+	for _, r := range repairs {
+		line := sf.Lines[r.lineIndex]
+		sf.Lines[r.lineIndex] = parseLine(line.Text[:r.offset]+r.text+line.Text[r.offset:], line.LineNumber)
+	}
+	return count
+}
+
+func (sf *StringsFile) syntaxError(lineIndex int, offset int, message string, repairs ...syntaxRepair) SyntaxError {
+	text := sf.Lines[lineIndex].Text
+	return SyntaxError{
+		Line:    sf.Lines[lineIndex].LineNumber,
+		Column:  utf8.RuneCountInString(text[:min(offset, len(text))]) + 1,
+		Message: message,
+		repairs: repairs,
+	}
+}
+
+// tokenize splits the file into tokens and reports unbalanced comments
+func (sf *StringsFile) tokenize() ([]token, []SyntaxError) {
+	var tokens []token
+	var errors []SyntaxError
+	inComment := false
+	var commentLine, commentOffset int
+
+	for lineIndex := 0; lineIndex < len(sf.Lines); lineIndex++ {
+		text := sf.Lines[lineIndex].Text
+		i := 0
+		if inComment {
+			end := strings.Index(text, "*/")
+			if end < 0 {
+				continue
+			}
+			inComment = false
+			i = end + 2
+		}
+
+		for i < len(text) {
+			c := text[i]
+			switch {
+			case c == ' ' || c == '\t' || c == '\r':
+				i++
+			case strings.HasPrefix(text[i:], "//"):
+				i = len(text)
+			case strings.HasPrefix(text[i:], "/*"):
+				end := strings.Index(text[i+2:], "*/")
+				if end < 0 {
+					inComment, commentLine, commentOffset = true, lineIndex, i
+					i = len(text)
+				} else {
+					i += end + 4
+				}
+			case strings.HasPrefix(text[i:], "*/"):
+				errors = append(errors, sf.syntaxError(lineIndex, i, "comment end `*/` without comment start"))
+				i += 2
+			case c == '"':
+				t := sf.scanString(lineIndex, i)
+				tokens = append(tokens, t)
+				lineIndex, text, i = t.endLine, sf.Lines[t.endLine].Text, t.end
+			case c == '=' || c == ';':
+				kind := tokenEquals
+				if c == ';' {
+					kind = tokenSemicolon
+				}
+				tokens = append(tokens, token{kind: kind, text: string(c), lineIndex: lineIndex, offset: i, endLine: lineIndex, end: i + 1})
+				i++
+			case isWordByte(c):
+				j := i
+				for j < len(text) && isWordByte(text[j]) {
+					j++
+				}
+				tokens = append(tokens, token{kind: tokenWord, text: text[i:j], lineIndex: lineIndex, offset: i, endLine: lineIndex, end: j})
+				i = j
+			default:
+				_, size := utf8.DecodeRuneInString(text[i:])
+				j := i + size
+				for j < len(text) && !isWordByte(text[j]) && strings.IndexByte(" \t\"=;/", text[j]) < 0 {
+					_, size = utf8.DecodeRuneInString(text[j:])
+					j += size
+				}
+				tokens = append(tokens, token{kind: tokenOther, text: text[i:j], lineIndex: lineIndex, offset: i, endLine: lineIndex, end: j})
+				i = j
+			}
+		}
+	}
+
+	if inComment {
+		errors = append(errors, sf.syntaxError(commentLine, commentOffset, "unterminated comment, `*/` is missing"))
+	}
+	return tokens, errors
+}
+
+// scanString returns the string starting with the quote at the given offset. Strings may span several lines,
+// unless the next line starts a new entry, which makes a missing closing quote more likely than a line break.
+func (sf *StringsFile) scanString(lineIndex int, offset int) token {
+	t := token{kind: tokenString, lineIndex: lineIndex, offset: offset}
+	var text strings.Builder
+	from := offset
+	for l := lineIndex; ; l++ {
+		line := sf.Lines[l].Text
+		j := from
+		if l == lineIndex {
+			j++
+		}
+		for ; j < len(line); j++ {
+			if line[j] == '\\' {
+				j++
+			} else if line[j] == '"' {
+				text.WriteString(line[from : j+1])
+				t.text, t.endLine, t.end = text.String(), l, j+1
+				return t
+			}
+		}
+
+		text.WriteString(line[from:])
+		if l+1 == len(sf.Lines) || entryStartRegex.MatchString(sf.Lines[l+1].Text) {
+			t.text, t.endLine, t.end, t.unterminated = text.String(), l, len(line), true
+			return t
+		}
+		text.WriteString("\n")
+		from = 0
+	}
+}
+
+// parseEntries checks that the tokens form entries like "key" = "value";
+func (sf *StringsFile) parseEntries(tokens []token) []SyntaxError {
+	const (
+		expectKey = iota
+		expectEquals
+		expectValue
+		expectSemicolon
+	)
+
+	var errors []SyntaxError
+	state := expectKey
+	skipLine := -1 // tokens of a line with an error are skipped
+	var value token
+
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if t.lineIndex == skipLine {
+			continue
+		}
+		fail := func(message string, repairs ...syntaxRepair) {
+			errors = append(errors, sf.syntaxError(t.lineIndex, t.offset, message, repairs...))
+			state = expectKey
+			skipLine = t.lineIndex
+		}
+
+		if t.kind == tokenString && t.unterminated {
+			// "key" = "value; is repaired by closing the string before the semicolon of its last line
+			lastLine := t.text[strings.LastIndex(t.text, "\n")+1:]
+			start := 0
+			if t.endLine == t.lineIndex {
+				start = t.offset
+			}
+			trimmed := strings.TrimRight(lastLine, " \t")
+			if state == expectValue && strings.HasSuffix(trimmed, ";") && strings.Count(trimmed, ";") == 1 {
+				fail("unterminated string, `\"` is missing", syntaxRepair{t.endLine, start + len(trimmed) - 1, `"`})
+			} else {
+				fail("unterminated string, `\"` is missing")
+			}
+			continue
+		}
+
+		switch state {
+		case expectKey:
+			switch {
+			case t.kind == tokenString:
+				state = expectEquals
+			case t.kind == tokenWord && i+1 < len(tokens) && tokens[i+1].kind == tokenEquals && tokens[i+1].lineIndex == t.lineIndex:
+				errors = append(errors, sf.syntaxError(t.lineIndex, t.offset, fmt.Sprintf("key `%s` is not quoted", t.text),
+					syntaxRepair{t.lineIndex, t.offset, `"`}, syntaxRepair{t.lineIndex, t.end, `"`}))
+				state = expectEquals
+			default:
+				fail(fmt.Sprintf("unexpected text `%s`", t.text))
+			}
+		case expectEquals:
+			if t.kind == tokenEquals {
+				state = expectValue
+			} else {
+				fail(fmt.Sprintf("expected `=` after the key, found `%s`", t.text))
+			}
+		case expectValue:
+			switch t.kind {
+			case tokenString:
+				value, state = t, expectSemicolon
+			case tokenWord:
+				errors = append(errors, sf.syntaxError(t.lineIndex, t.offset, fmt.Sprintf("value `%s` is not quoted", t.text)))
+				value, state = t, expectSemicolon
+			default:
+				fail(fmt.Sprintf("expected a value, found `%s`", t.text))
+			}
+		case expectSemicolon:
+			if t.kind == tokenSemicolon {
+				state = expectKey
+				continue
+			}
+			errors = append(errors, sf.missingSemicolon(value))
+			// the token starts the next entry
+			state = expectKey
+			i--
+		}
+	}
+
+	switch state {
+	case expectSemicolon:
+		errors = append(errors, sf.missingSemicolon(value))
+	case expectEquals, expectValue:
+		last := tokens[len(tokens)-1]
+		errors = append(errors, sf.syntaxError(last.endLine, last.end, "incomplete entry at the end of the file"))
+	}
+	return errors
+}
+
+// missingSemicolon reports a missing semicolon after the value, which is repairable if only a comment follows the value
+func (sf *StringsFile) missingSemicolon(value token) SyntaxError {
+	rest := strings.TrimSpace(sf.Lines[value.endLine].Text[value.end:])
+	if value.kind == tokenString && (rest == "" || strings.HasPrefix(rest, "//") || strings.HasPrefix(rest, "/*")) {
+		return sf.syntaxError(value.endLine, value.end, "missing `;` after the value", syntaxRepair{value.endLine, value.end, ";"})
+	}
+	return sf.syntaxError(value.endLine, value.end, "missing `;` after the value")
+}
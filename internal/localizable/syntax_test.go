@@ -0,0 +1,85 @@
+package localizable
+
+import (
+	"strings"
+	"testing"
+)
+
+func parseTestFile(t *testing.T, content string) *StringsFile {
+	t.Helper()
+	file, err := ParseStringsFile("Localizable.strings", []byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestTokenizeStrings(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		text         string // text of the value token
+		endLine      int
+		unterminated bool
+	}{
+		{"single line", `"k" = "value";`, `"value"`, 0, false},
+		{"escaped quote", `"k" = "say \"hi\"";`, `"say \"hi\""`, 0, false},
+		{"multi-line value", "\"k\" = \"first part;\nsecond part\";", "\"first part;\nsecond part\"", 1, false},
+		{"unterminated at end of file", "\"k\" = \"first;\nsecond;", "\"first;\nsecond;", 1, true},
+		{"unterminated before entry", "\"k\" = \"value;\n\"next\" = \"x\";", `"value;`, 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tokens, _ := parseTestFile(t, test.content).tokenize()
+			if len(tokens) < 3 {
+				t.Fatalf("expected at least 3 tokens, got %d", len(tokens))
+			}
+			value := tokens[2]
+			if value.kind != tokenString || value.text != test.text || value.endLine != test.endLine || value.unterminated != test.unterminated {
+				t.Errorf("got %q ending in line %d (unterminated %v), want %q ending in line %d (unterminated %v)",
+					value.text, value.endLine, value.unterminated, test.text, test.endLine, test.unterminated)
+			}
+		})
+	}
+}
+
+func TestRepairSyntax(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		errors   int
+		repaired string // content after repairing, empty if nothing is repairable
+	}{
+		{"valid", `"k" = "v";`, 0, ""},
+		{"multi-line value is valid", "\"k\" = \"first part;\nsecond part\";\n\"a\" = \"b\";", 0, ""},
+		{"missing semicolon", `"k" = "v"`, 1, `"k" = "v";`},
+		{"missing semicolon before comment", `"k" = "v" // note`, 1, `"k" = "v"; // note`},
+		{"missing semicolon before text", `"k" = "v" x`, 2, ""},
+		{"unquoted key", `key = "v";`, 1, `"key" = "v";`},
+		{"unterminated before entry", "\"k\" = \"v;\n\"a\" = \"b\";", 1, "\"k\" = \"v\";\n\"a\" = \"b\";"},
+		{"unterminated at end of file", "\"a\" = \"b\";\n\"k\" = \"v;", 1, "\"a\" = \"b\";\n\"k\" = \"v\";"},
+		{"unterminated with several semicolons", "\"k\" = \"a; b;\n\"a\" = \"b\";", 1, ""},
+		{"unterminated comment", "/* comment\n\"k\" = \"v\";", 1, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file := parseTestFile(t, test.content)
+			if errors := file.SyntaxErrors(); len(errors) != test.errors {
+				t.Fatalf("got %d syntax errors, want %d: %v", len(errors), test.errors, errors)
+			}
+
+			repaired := file.RepairSyntax(func(SyntaxError) bool { return true })
+			content := strings.TrimSuffix(file.Content(), "\n")
+			switch {
+			case test.repaired == "" && repaired > 0:
+				t.Errorf("repaired %d errors, want none: %q", repaired, content)
+			case test.repaired != "" && content != test.repaired:
+				t.Errorf("got %q, want %q", content, test.repaired)
+			case test.repaired != "" && len(file.SyntaxErrors()) > 0:
+				t.Errorf("syntax errors remain after repairing: %v", file.SyntaxErrors())
+			}
+		})
+	}
+}
@@ -0,0 +1,377 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// product types of targets which are not shipped and therefore not analyzed
+var ignoredProductTypes = []string{
+	"com.apple.product-type.bundle.unit-test",
+	"com.apple.product-type.bundle.ui-testing",
+}
+
+// pbxObject is an object of the objects dictionary of a project.pbxproj file
+type pbxObject map[string]any
+
+func (o pbxObject) isa() string {
+	return o.string("isa")
+}
+
+func (o pbxObject) string(key string) string {
+	value, _ := o[key].(string)
+	return value
+}
+
+func (o pbxObject) strings(key string) []string {
+	values, _ := o[key].([]any)
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// xcodeProject is a parsed .xcodeproj
+type xcodeProject struct {
+	path    string // path of the .xcodeproj directory
+	objects map[string]pbxObject
+	root    pbxObject
+	parents map[string]string // object id -> id of the group containing it
+}
+
+// ReadXcodeProjectTargets reads the native targets of an .xcodeproj together with
+// their Swift sources and bundled .strings files. Test targets are skipped.
+func ReadXcodeProjectTargets(projectPath string) ([]*Target, error) {
+	project, err := readXcodeProject(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	developmentRegion := project.root.string("developmentRegion")
+
+	var targets []*Target
+	for _, targetID := range project.root.strings("targets") {
+		object := project.objects[targetID]
+		if object.isa() != "PBXNativeTarget" || Contains(ignoredProductTypes, object.string("productType")) {
+			continue
+		}
+
+		target := &Target{
+			Name:              object.string("name"),
+			Project:           projectPath,
+			DevelopmentRegion: developmentRegion,
+		}
+
+		for _, phaseID := range object.strings("buildPhases") {
+			phase := project.objects[phaseID]
+			for _, buildFileID := range phase.strings("files") {
+				fileRef := project.objects[buildFileID].string("fileRef")
+				switch phase.isa() {
+				case "PBXSourcesBuildPhase":
+					if path := project.resolvePath(fileRef); strings.HasSuffix(path, ".swift") {
+						target.SourcePaths = append(target.SourcePaths, path)
+					}
+				case "PBXResourcesBuildPhase":
+					target.StringsFiles = append(target.StringsFiles, project.stringsFiles(fileRef)...)
+				}
+			}
+		}
+
+		// folders synchronized with the file system (Xcode 16) contribute all of their files
+		for _, groupID := range object.strings("fileSystemSynchronizedGroups") {
+			if dir := project.resolvePath(groupID); dir != "" {
+				target.SourcePaths = append(target.SourcePaths, dir)
+				stringsFiles, err := findLocalizedStringsFiles(dir)
+				if err != nil && !os.IsNotExist(err) {
+					return nil, err
+				}
+				target.StringsFiles = append(target.StringsFiles, stringsFiles...)
+			}
+		}
+
+		target.StringsFiles = uniqueSorted(target.StringsFiles)
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+func readXcodeProject(projectPath string) (*xcodeProject, error) {
+	content, err := os.ReadFile(filepath.Join(projectPath, "project.pbxproj"))
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := parseOpenStepPlist(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", projectPath, err)
+	}
+	plist, ok := parsed.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("error parsing %s: unexpected root object", projectPath)
+	}
+
+	project := &xcodeProject{
+		path:    projectPath,
+		objects: map[string]pbxObject{},
+		parents: map[string]string{},
+	}
+
+	objects, _ := plist["objects"].(map[string]any)
+	for id, value := range objects {
+		if object, ok := value.(map[string]any); ok {
+			project.objects[id] = object
+		}
+	}
+	for id, object := range project.objects {
+		for _, child := range object.strings("children") {
+			project.parents[child] = id
+		}
+	}
+
+	rootID, _ := plist["rootObject"].(string)
+	project.root = project.objects[rootID]
+	if project.root == nil {
+		return nil, fmt.Errorf("error parsing %s: root object not found", projectPath)
+	}
+
+	return project, nil
+}
+
+// resolvePath returns the path of a file reference or group relative to the working directory.
+// It returns an empty string for references relative to build settings like BUILT_PRODUCTS_DIR.
+func (p *xcodeProject) resolvePath(id string) string {
+	object, ok := p.objects[id]
+	if !ok {
+		return ""
+	}
+
+	projectDir := filepath.Dir(p.path)
+	path := object.string("path")
+
+	switch object.string("sourceTree") {
+	case "<absolute>":
+		return path
+	case "SOURCE_ROOT":
+		return filepath.Join(projectDir, path)
+	case "<group>":
+		parentID, ok := p.parents[id]
+		if !ok {
+			// the main group is relative to the project directory
+			return filepath.Join(projectDir, p.root.string("projectDirPath"), path)
+		}
+		parentPath := p.resolvePath(parentID)
+		if parentPath == "" {
+			return ""
+		}
+		return filepath.Join(parentPath, path)
+	default:
+		return ""
+	}
+}
+
+// stringsFiles returns the .strings files of a resource, which is either a file reference
+// or a variant group holding one file per locale.
+func (p *xcodeProject) stringsFiles(id string) []string {
+	object := p.objects[id]
+
+	var ids []string
+	switch object.isa() {
+	case "PBXVariantGroup":
+		ids = object.strings("children")
+	case "PBXFileReference":
+		ids = []string{id}
+	}
+
+	var files []string
+	for _, fileID := range ids {
+		if path := p.resolvePath(fileID); filepath.Ext(path) == ".strings" {
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// parseOpenStepPlist parses the old-style ASCII property list format used by project.pbxproj files.
+// Dictionaries are returned as map[string]any, arrays as []any and all scalars as string.
+func parseOpenStepPlist(content string) (any, error) {
+	parser := &plistParser{content: content}
+	value, err := parser.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+type plistParser struct {
+	content string
+	pos     int
+}
+
+func (p *plistParser) parseValue() (any, error) {
+	p.skipWhitespaceAndComments()
+	if p.pos >= len(p.content) {
+		return nil, fmt.Errorf("unexpected end of file")
+	}
+
+	switch p.content[p.pos] {
+	case '{':
+		return p.parseDictionary()
+	case '(':
+		return p.parseArray()
+	case '"':
+		return p.parseQuotedString()
+	default:
+		return p.parseUnquotedString()
+	}
+}
+
+func (p *plistParser) parseDictionary() (map[string]any, error) {
+	p.pos++ // {
+	dict := map[string]any{}
+	for {
+		p.skipWhitespaceAndComments()
+		if p.pos >= len(p.content) {
+			return nil, fmt.Errorf("unterminated dictionary")
+		}
+		if p.content[p.pos] == '}' {
+			p.pos++
+			return dict, nil
+		}
+
+		key, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		keyString, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid dictionary key at offset %d", p.pos)
+		}
+
+		if err := p.expect('='); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(';'); err != nil {
+			return nil, err
+		}
+		dict[keyString] = value
+	}
+}
+
+func (p *plistParser) parseArray() ([]any, error) {
+	p.pos++ // (
+	array := []any{}
+	for {
+		p.skipWhitespaceAndComments()
+		if p.pos >= len(p.content) {
+			return nil, fmt.Errorf("unterminated array")
+		}
+		if p.content[p.pos] == ')' {
+			p.pos++
+			return array, nil
+		}
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		array = append(array, value)
+
+		p.skipWhitespaceAndComments()
+		if p.pos < len(p.content) && p.content[p.pos] == ',' {
+			p.pos++
+		}
+	}
+}
+
+func (p *plistParser) parseQuotedString() (string, error) {
+	p.pos++ // opening quote
+	var sb strings.Builder
+	for p.pos < len(p.content) {
+		c := p.content[p.pos]
+		switch c {
+		case '"':
+			p.pos++
+			return sb.String(), nil
+		case '\\':
+			p.pos++
+			if p.pos >= len(p.content) {
+				return "", fmt.Errorf("unterminated string")
+			}
+			switch escaped := p.content[p.pos]; escaped {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(escaped)
+			}
+		default:
+			sb.WriteByte(c)
+		}
+		p.pos++
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+func (p *plistParser) parseUnquotedString() (string, error) {
+	start := p.pos
+	for p.pos < len(p.content) && isUnquotedPlistChar(p.content[p.pos]) {
+		p.pos++
+	}
+	if start == p.pos {
+		return "", fmt.Errorf("unexpected character %q at offset %d", p.content[p.pos], p.pos)
+	}
+	return p.content[start:p.pos], nil
+}
+
+func (p *plistParser) expect(c byte) error {
+	p.skipWhitespaceAndComments()
+	if p.pos >= len(p.content) || p.content[p.pos] != c {
+		return fmt.Errorf("expected %q at offset %d", c, p.pos)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *plistParser) skipWhitespaceAndComments() {
+	for p.pos < len(p.content) {
+		rest := p.content[p.pos:]
+		switch {
+		case rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\n' || rest[0] == '\r':
+			p.pos++
+		case strings.HasPrefix(rest, "//"):
+			end := strings.IndexByte(rest, '\n')
+			if end == -1 {
+				p.pos = len(p.content)
+			} else {
+				p.pos += end + 1
+			}
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end == -1 {
+				p.pos = len(p.content)
+			} else {
+				p.pos += end + 4
+			}
+		default:
+			return
+		}
+	}
+}
+
+func isUnquotedPlistChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '$' || c == '+' || c == '/' || c == ':' || c == '.' || c == '-'
+}
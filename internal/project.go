@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+type ProjectKind string
+
+const (
+	ProjectKindXcode        ProjectKind = "xcodeproj"
+	ProjectKindSwiftPackage ProjectKind = "package"
+)
+
+// Project describes the Xcode project or Swift package a command is run in.
+type Project struct {
+	Kind              ProjectKind
+	Path              string // path to the .xcodeproj directory or the Package.swift file
+	Dir               string // directory containing the project
+	DevelopmentRegion string // developmentRegion or defaultLocalization of the project
+}
+
+var ErrProjectNotFound = errors.New("no .xcodeproj or Package.swift with a development region found")
+
+var defaultLocalizationRegex = regexp.MustCompile(`defaultLocalization\s*:\s*"([^"]+)"`)
+
+// FindProject walks up from startDir until it finds an .xcodeproj
+// or a Package.swift declaring a development region.
+func FindProject(startDir string) (*Project, error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		project, err := projectInDirectory(dir)
+		if err != nil {
+			return nil, err
+		}
+		if project != nil {
+			return project, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, ErrProjectNotFound
+		}
+		dir = parent
+	}
+}
+
+// projectInDirectory returns the project located directly in dir or nil if there is none.
+func projectInDirectory(dir string) (*Project, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Xcode projects take precedence over packages in the same directory
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasSuffix(entry.Name(), ".xcodeproj") {
+			continue
+		}
+		projectPath := filepath.Join(dir, entry.Name())
+		region, err := findDevelopmentRegionInPbxProj(filepath.Join(projectPath, "project.pbxproj"))
+		if err != nil {
+			continue
+		}
+		return &Project{
+			Kind:              ProjectKindXcode,
+			Path:              projectPath,
+			Dir:               dir,
+			DevelopmentRegion: region,
+		}, nil
+	}
+
+	packagePath := filepath.Join(dir, "Package.swift")
+	if _, err := os.Stat(packagePath); err == nil {
+		region, err := FindDefaultLocalizationInPackage(packagePath)
+		if err == nil {
+			return &Project{
+				Kind:              ProjectKindSwiftPackage,
+				Path:              packagePath,
+				Dir:               dir,
+				DevelopmentRegion: region,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// FindDefaultLocalizationInPackage reads the defaultLocalization of a Package.swift manifest.
+func FindDefaultLocalizationInPackage(packagePath string) (string, error) {
+	content, err := os.ReadFile(packagePath)
+	if err != nil {
+		return "", err
+	}
+
+	match := defaultLocalizationRegex.FindSubmatch(content)
+	if match == nil {
+		return "", fmt.Errorf("defaultLocalization not found in %s", packagePath)
+	}
+
+	return string(match[1]), nil
+}
@@ -0,0 +1,61 @@
+package report
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/phillippbertram/xc-strings/internal/rules"
+)
+
+type checkstyleResult struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// writeCheckstyle writes the diagnostics grouped by file in the Checkstyle XML format
+func writeCheckstyle(w io.Writer, diagnostics []rules.Diagnostic) error {
+	result := checkstyleResult{Version: "4.3"}
+
+	fileIndex := make(map[string]int)
+	for _, diagnostic := range diagnostics {
+		index, ok := fileIndex[diagnostic.Path]
+		if !ok {
+			index = len(result.Files)
+			fileIndex[diagnostic.Path] = index
+			result.Files = append(result.Files, checkstyleFile{Name: diagnostic.Path})
+		}
+
+		result.Files[index].Errors = append(result.Files[index].Errors, checkstyleError{
+			Line:     diagnostic.Line,
+			Column:   diagnostic.Column,
+			Severity: string(diagnostic.Severity),
+			Message:  diagnostic.Message,
+			Source:   ToolName + "." + diagnostic.RuleID,
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,53 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/phillippbertram/xc-strings/internal/rules"
+)
+
+// writeGitHub writes the diagnostics as GitHub Actions workflow commands, which annotate the lines of a pull request.
+// See https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions
+func writeGitHub(w io.Writer, diagnostics []rules.Diagnostic) error {
+	for _, diagnostic := range diagnostics {
+		properties := []string{"file=" + escapeGitHubProperty(diagnostic.Path)}
+		if diagnostic.Line > 0 {
+			properties = append(properties, fmt.Sprintf("line=%d", diagnostic.Line))
+		}
+		if diagnostic.Column > 0 {
+			properties = append(properties, fmt.Sprintf("col=%d", diagnostic.Column))
+		}
+		properties = append(properties, "title="+escapeGitHubProperty(fmt.Sprintf("%s (%s)", ToolName, diagnostic.RuleID)))
+
+		_, err := fmt.Fprintf(w, "::%s %s::%s\n", gitHubCommand(diagnostic.Severity), strings.Join(properties, ","), escapeGitHubData(diagnostic.Message))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func gitHubCommand(severity rules.Severity) string {
+	switch severity {
+	case rules.SeverityError:
+		return "error"
+	case rules.SeverityInfo:
+		return "notice"
+	default:
+		return "warning"
+	}
+}
+
+func escapeGitHubData(value string) string {
+	value = strings.ReplaceAll(value, "%", "%25")
+	value = strings.ReplaceAll(value, "\r", "%0D")
+	return strings.ReplaceAll(value, "\n", "%0A")
+}
+
+func escapeGitHubProperty(value string) string {
+	value = escapeGitHubData(value)
+	value = strings.ReplaceAll(value, ":", "%3A")
+	return strings.ReplaceAll(value, ",", "%2C")
+}
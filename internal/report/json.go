@@ -0,0 +1,69 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/phillippbertram/xc-strings/internal/rules"
+)
+
+// JSONSchemaVersion is incremented whenever the structure of the JSON report changes incompatibly
+const JSONSchemaVersion = 1
+
+type jsonReport struct {
+	Version     int              `json:"version"`
+	Tool        string           `json:"tool"`
+	Command     string           `json:"command"`
+	Summary     jsonSummary      `json:"summary"`
+	Diagnostics []jsonDiagnostic `json:"diagnostics"`
+}
+
+type jsonSummary struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+	Infos    int `json:"infos"`
+}
+
+type jsonDiagnostic struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Path     string `json:"path"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Key      string `json:"key,omitempty"`
+	Message  string `json:"message"`
+}
+
+func writeJSON(w io.Writer, command string, diagnostics []rules.Diagnostic) error {
+	report := jsonReport{
+		Version:     JSONSchemaVersion,
+		Tool:        ToolName,
+		Command:     command,
+		Diagnostics: make([]jsonDiagnostic, 0, len(diagnostics)),
+	}
+
+	for _, diagnostic := range diagnostics {
+		switch diagnostic.Severity {
+		case rules.SeverityError:
+			report.Summary.Errors++
+		case rules.SeverityWarning:
+			report.Summary.Warnings++
+		default:
+			report.Summary.Infos++
+		}
+
+		report.Diagnostics = append(report.Diagnostics, jsonDiagnostic{
+			Rule:     diagnostic.RuleID,
+			Severity: string(diagnostic.Severity),
+			Path:     diagnostic.Path,
+			Line:     diagnostic.Line,
+			Column:   diagnostic.Column,
+			Key:      diagnostic.Key,
+			Message:  diagnostic.Message,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
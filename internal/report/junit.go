@@ -0,0 +1,84 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/phillippbertram/xc-strings/internal/rules"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit writes one test suite per rule and one test case per diagnostic.
+// Diagnostics with severity info are reported as passed test cases.
+func writeJUnit(w io.Writer, command string, diagnostics []rules.Diagnostic) error {
+	suites := junitTestSuites{Name: fmt.Sprintf("%s %s", ToolName, command)}
+
+	for _, id := range ruleIDs(diagnostics) {
+		suite := junitTestSuite{Name: id}
+		for _, diagnostic := range diagnostics {
+			if diagnostic.RuleID != id {
+				continue
+			}
+
+			testCase := junitTestCase{
+				Name:      fmt.Sprintf("%s %s", diagnostic.Location(), diagnostic.Key),
+				ClassName: diagnostic.Path,
+			}
+			if diagnostic.Severity == rules.SeverityInfo {
+				testCase.SystemOut = diagnostic.Message
+			} else {
+				testCase.Failure = &junitFailure{
+					Message: diagnostic.Message,
+					Type:    string(diagnostic.Severity),
+					Text:    fmt.Sprintf("%s: %s", diagnostic.Location(), diagnostic.Message),
+				}
+				suite.Failures++
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+			suite.Tests++
+		}
+
+		suites.Suites = append(suites.Suites, suite)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
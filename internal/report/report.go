@@ -0,0 +1,98 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/phillippbertram/xc-strings/internal/rules"
+)
+
+// Format is the output format of diagnostics
+type Format string
+
+const (
+	FormatText       Format = "text"
+	FormatJSON       Format = "json"
+	FormatSARIF      Format = "sarif"
+	FormatJUnit      Format = "junit"
+	FormatCheckstyle Format = "checkstyle"
+	FormatGitHub     Format = "github"
+)
+
+// ToolName is the name of the tool in reports
+const ToolName = "xcs"
+
+var formats = []Format{FormatText, FormatJSON, FormatSARIF, FormatJUnit, FormatCheckstyle, FormatGitHub}
+
+// Formats returns the names of all supported formats
+func Formats() []string {
+	names := make([]string, 0, len(formats))
+	for _, format := range formats {
+		names = append(names, string(format))
+	}
+	return names
+}
+
+// ParseFormat parses a format like "json" or "sarif"
+func ParseFormat(value string) (Format, error) {
+	for _, format := range formats {
+		if string(format) == value {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format '%s' (%s)", value, strings.Join(Formats(), ", "))
+}
+
+// IsMachineReadable reports whether the format is meant to be consumed by other tools.
+// Text output is printed by the commands themselves.
+func (f Format) IsMachineReadable() bool {
+	return f != "" && f != FormatText
+}
+
+// Write writes the diagnostics found by a command in the given machine-readable format
+func Write(w io.Writer, format Format, command string, diagnostics []rules.Diagnostic) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, command, diagnostics)
+	case FormatSARIF:
+		return writeSARIF(w, diagnostics)
+	case FormatJUnit:
+		return writeJUnit(w, command, diagnostics)
+	case FormatCheckstyle:
+		return writeCheckstyle(w, diagnostics)
+	case FormatGitHub:
+		return writeGitHub(w, diagnostics)
+	default:
+		return fmt.Errorf("format '%s' is not machine-readable", format)
+	}
+}
+
+// ruleIDs returns the IDs of the rules which reported the diagnostics, in order of appearance
+func ruleIDs(diagnostics []rules.Diagnostic) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, diagnostic := range diagnostics {
+		if !seen[diagnostic.RuleID] {
+			seen[diagnostic.RuleID] = true
+			ids = append(ids, diagnostic.RuleID)
+		}
+	}
+	return ids
+}
+
+// descriptions of findings reported by commands without a rule
+var findingDescriptions = map[string]string{
+	"missing": "Keys of the base file must be translated in every locale",
+}
+
+// ruleDescription returns the description of a registered rule or of a finding of a command without a rule
+func ruleDescription(id string) string {
+	if rule, ok := rules.Get(id); ok {
+		return rule.Description()
+	}
+	if description, ok := findingDescriptions[id]; ok {
+		return description
+	}
+	return id
+}
@@ -0,0 +1,72 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+// DuplicatesRule reports keys which are defined more than once in a file
+type DuplicatesRule struct{}
+
+func init() {
+	Register(DuplicatesRule{})
+}
+
+func (DuplicatesRule) ID() string { return "duplicates" }
+func (DuplicatesRule) Description() string {
+	return "Keys must not be defined more than once in a file"
+}
+func (DuplicatesRule) DefaultSeverity() Severity { return SeverityError }
+
+func (DuplicatesRule) Check(ctx *Context) ([]Diagnostic, error) {
+	var diagnostics []Diagnostic
+	for _, file := range ctx.Files {
+		for key, lines := range file.FindDuplicateKeys() {
+			for _, line := range lines[1:] {
+				diagnostics = append(diagnostics, Diagnostic{
+					Path:    file.Path,
+					Line:    line.LineNumber,
+					Column:  line.Column(),
+					Key:     key,
+					Message: fmt.Sprintf("duplicate key `%s`, first defined at line %d", key, lines[0].LineNumber),
+				})
+			}
+		}
+	}
+	return diagnostics, nil
+}
+
+// Fix removes duplicate definitions of keys whose definitions all share the same value.
+// Duplicates with different values need a decision which value is correct.
+func (r DuplicatesRule) Fix(ctx *Context, file *localizable.StringsFile) int {
+	var removed []localizable.Line
+	for _, lines := range file.FindDuplicateKeys() {
+		if !sameValues(lines) || anySuppressed(r.ID(), file, lines) {
+			continue
+		}
+		// the last definition wins at runtime, so it is kept
+		removed = append(removed, lines[:len(lines)-1]...)
+	}
+
+	file.RemoveLines(removed)
+	return len(removed)
+}
+
+func sameValues(lines []localizable.Line) bool {
+	for _, line := range lines[1:] {
+		if line.RawValue() != lines[0].RawValue() {
+			return false
+		}
+	}
+	return true
+}
+
+func anySuppressed(ruleID string, file *localizable.StringsFile, lines []localizable.Line) bool {
+	for _, line := range lines {
+		if file.IsSuppressed(ruleID, line.LineNumber) {
+			return true
+		}
+	}
+	return false
+}
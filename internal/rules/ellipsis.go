@@ -0,0 +1,46 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+// EllipsisRule reports three dots which should be the ellipsis character
+type EllipsisRule struct{}
+
+func init() {
+	Register(EllipsisRule{})
+}
+
+func (EllipsisRule) ID() string                { return "ellipsis" }
+func (EllipsisRule) Description() string       { return "Values must use … instead of ..." }
+func (EllipsisRule) DefaultSeverity() Severity { return SeverityWarning }
+func (EllipsisRule) OptIn() bool               { return true }
+
+func (EllipsisRule) Check(ctx *Context) ([]Diagnostic, error) {
+	var diagnostics []Diagnostic
+	for _, file := range ctx.Files {
+		for _, line := range file.Lines {
+			if line.Key == "" || !strings.Contains(line.RawValue(), "...") {
+				continue
+			}
+			diagnostics = append(diagnostics, Diagnostic{
+				Path:    file.Path,
+				Line:    line.LineNumber,
+				Column:  line.Column(),
+				Key:     line.Key,
+				Message: fmt.Sprintf("value of key `%s` uses ... instead of …", line.Key),
+			})
+		}
+	}
+	return diagnostics, nil
+}
+
+// Fix replaces three dots by the ellipsis character
+func (r EllipsisRule) Fix(ctx *Context, file *localizable.StringsFile) int {
+	return fixRawValues(r.ID(), file, func(value string) string {
+		return strings.ReplaceAll(value, "...", "…")
+	})
+}
@@ -0,0 +1,30 @@
+package rules
+
+import "fmt"
+
+// EmptyValuesRule reports keys with an empty value
+type EmptyValuesRule struct{}
+
+func init() {
+	Register(EmptyValuesRule{})
+}
+
+func (EmptyValuesRule) ID() string                { return "emptyValues" }
+func (EmptyValuesRule) Description() string       { return "Values must not be empty" }
+func (EmptyValuesRule) DefaultSeverity() Severity { return SeverityWarning }
+
+func (EmptyValuesRule) Check(ctx *Context) ([]Diagnostic, error) {
+	var diagnostics []Diagnostic
+	for _, file := range ctx.Files {
+		for _, line := range file.EmptyValues() {
+			diagnostics = append(diagnostics, Diagnostic{
+				Path:    file.Path,
+				Line:    line.LineNumber,
+				Column:  line.Column(),
+				Key:     line.Key,
+				Message: fmt.Sprintf("empty value for key `%s`", line.Key),
+			})
+		}
+	}
+	return diagnostics, nil
+}
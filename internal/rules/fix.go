@@ -0,0 +1,125 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+// Fixer is implemented by rules whose diagnostics can be corrected automatically with `xcs fix` or `xcs check --fix`
+type Fixer interface {
+	Rule
+	// Fix corrects the issues of the rule in the file in memory and returns the number of fixed issues.
+	// Lines suppressed for the rule must be left untouched.
+	Fix(ctx *Context, file *localizable.StringsFile) int
+}
+
+// IsFixable reports whether the diagnostics of the rule can be fixed automatically
+func IsFixable(rule Rule) bool {
+	_, ok := rule.(Fixer)
+	return ok
+}
+
+// FixResult is the outcome of fixing a single file
+type FixResult struct {
+	File     *localizable.StringsFile
+	Original string         // content of the file before the fixes
+	Fixes    map[string]int // number of fixed issues by rule
+}
+
+// Count returns the number of fixed issues of all rules
+func (r FixResult) Count() int {
+	count := 0
+	for _, fixes := range r.Fixes {
+		count += fixes
+	}
+	return count
+}
+
+// Describe returns a short summary of the fixes like "3 sorting, 1 whitespace"
+func (r FixResult) Describe() string {
+	ids := make([]string, 0, len(r.Fixes))
+	for id := range r.Fixes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, fmt.Sprintf("%d %s", r.Fixes[id], id))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Fix applies the fixes of the fixable rules to the files of the context in memory and returns the changed files.
+// Files with syntax errors which cannot be repaired or with multi-line strings are not fixed otherwise.
+func Fix(ctx *Context, rules []Rule) ([]FixResult, error) {
+	var fixers []Fixer
+	for _, rule := range rules {
+		if fixer, ok := rule.(Fixer); ok {
+			fixers = append(fixers, fixer)
+		}
+	}
+	sort.SliceStable(fixers, func(i, j int) bool {
+		return fixPhase(fixers[i]) < fixPhase(fixers[j])
+	})
+
+	var results []FixResult
+	for _, file := range ctx.Files {
+		result := FixResult{File: file, Original: file.Content(), Fixes: map[string]int{}}
+		for _, fixer := range fixers {
+			if ctx.isDisabledFor(fixer.ID(), file.Path) {
+				continue
+			}
+			// entries of files with syntax errors or multi-line strings cannot be read reliably, only the syntax is repaired
+			if fixPhase(fixer) > fixPhaseSyntax && (len(file.SyntaxErrors()) > 0 || file.HasMultilineStrings()) {
+				break
+			}
+			if count := fixer.Fix(ctx, file); count > 0 {
+				result.Fixes[fixer.ID()] += count
+			}
+		}
+
+		if file.Content() != result.Original {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+// Fixes run in phases: syntax repairs first, so that the other fixes can read the entries,
+// and fixes which reorder lines last, so that the other fixes can rely on the original line numbers
+const (
+	fixPhaseSyntax  = -1
+	fixPhaseDefault = 0
+	fixPhaseReorder = 1
+)
+
+// phasedFixer is implemented by fixers which do not run in the default phase
+type phasedFixer interface {
+	fixPhase() int
+}
+
+func fixPhase(rule Rule) int {
+	if phased, ok := rule.(phasedFixer); ok {
+		return phased.fixPhase()
+	}
+	return fixPhaseDefault
+}
+
+// fixRawValues replaces the values of all entries which are not suppressed for the rule and returns the number of changed values
+func fixRawValues(ruleID string, file *localizable.StringsFile, fix func(value string) string) int {
+	count := 0
+	for i, line := range file.Lines {
+		if line.Key == "" || file.IsSuppressed(ruleID, line.LineNumber) {
+			continue
+		}
+		if value := line.RawValue(); fix(value) != value {
+			file.Lines[i].SetRawValue(fix(value))
+			count++
+		}
+	}
+	return count
+}
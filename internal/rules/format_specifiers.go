@@ -0,0 +1,139 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+// FormatSpecifiersRule reports translations whose format specifiers do not match the base value.
+// A translation expecting other arguments than the code passes can crash the app at runtime.
+type FormatSpecifiersRule struct{}
+
+func init() {
+	Register(FormatSpecifiersRule{})
+}
+
+func (FormatSpecifiersRule) ID() string { return "formatSpecifiers" }
+func (FormatSpecifiersRule) Description() string {
+	return "Format specifiers of translations must match the base value"
+}
+func (FormatSpecifiersRule) DefaultSeverity() Severity { return SeverityError }
+func (FormatSpecifiersRule) OptIn() bool               { return true }
+func (FormatSpecifiersRule) RequiresBase() bool        { return true }
+
+func (FormatSpecifiersRule) Check(ctx *Context) ([]Diagnostic, error) {
+	var diagnostics []Diagnostic
+	forEachTranslatedEntry(ctx, func(_ *localizable.Table, _, translation *localizable.Locale, key string, baseEntry, entry *localizable.Line) {
+		baseSpecifiers := localizable.ParseFormatSpecifiers(baseEntry.Value)
+		specifiers := localizable.ParseFormatSpecifiers(entry.Value)
+		for _, message := range compareFormatSpecifiers(baseSpecifiers, specifiers) {
+			diagnostics = append(diagnostics, Diagnostic{
+				Path:    translation.File.Path,
+				Line:    entry.LineNumber,
+				Column:  entry.Column(),
+				Key:     key,
+				Message: fmt.Sprintf("key `%s`: %s", key, message),
+			})
+		}
+	})
+	return diagnostics, nil
+}
+
+// compareFormatSpecifiers returns a message for each difference between the specifiers of the base and a translation
+func compareFormatSpecifiers(base []localizable.FormatSpecifier, translation []localizable.FormatSpecifier) []string {
+	// the same arguments in a different order need positional specifiers like %1$@
+	if !usesPositions(translation) && !sameTypeSequence(base, translation) && sameTypes(base, translation) {
+		return []string{fmt.Sprintf("specifiers %s are reordered without positions, use positional specifiers like %%1$@", specifierTexts(translation))}
+	}
+
+	baseArguments := argumentSpecifiers(base)
+	arguments := argumentSpecifiers(translation)
+
+	var messages []string
+	for _, argument := range sortedArguments(baseArguments) {
+		baseSpecifier := baseArguments[argument]
+		specifier, ok := arguments[argument]
+		switch {
+		case !ok:
+			messages = append(messages, fmt.Sprintf("missing specifier %s for argument %d", baseSpecifier.Text, argument))
+		case specifier.Type != baseSpecifier.Type:
+			messages = append(messages, fmt.Sprintf("specifier %s for argument %d does not match %s of the base value", specifier.Text, argument, baseSpecifier.Text))
+		}
+	}
+	for _, argument := range sortedArguments(arguments) {
+		if _, ok := baseArguments[argument]; !ok {
+			messages = append(messages, fmt.Sprintf("extra specifier %s for argument %d", arguments[argument].Text, argument))
+		}
+	}
+	return messages
+}
+
+// argumentSpecifiers returns the first specifier of each argument
+func argumentSpecifiers(specifiers []localizable.FormatSpecifier) map[int]localizable.FormatSpecifier {
+	arguments := make(map[int]localizable.FormatSpecifier)
+	for _, specifier := range specifiers {
+		if _, ok := arguments[specifier.Argument]; !ok {
+			arguments[specifier.Argument] = specifier
+		}
+	}
+	return arguments
+}
+
+func sortedArguments(arguments map[int]localizable.FormatSpecifier) []int {
+	indexes := make([]int, 0, len(arguments))
+	for index := range arguments {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
+
+func usesPositions(specifiers []localizable.FormatSpecifier) bool {
+	for _, specifier := range specifiers {
+		if specifier.Position {
+			return true
+		}
+	}
+	return false
+}
+
+func sameTypeSequence(a []localizable.FormatSpecifier, b []localizable.FormatSpecifier) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type {
+			return false
+		}
+	}
+	return true
+}
+
+// sameTypes reports whether both lists contain the same types regardless of their order
+func sameTypes(a []localizable.FormatSpecifier, b []localizable.FormatSpecifier) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int)
+	for _, specifier := range a {
+		counts[specifier.Type]++
+	}
+	for _, specifier := range b {
+		counts[specifier.Type]--
+		if counts[specifier.Type] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func specifierTexts(specifiers []localizable.FormatSpecifier) string {
+	texts := make([]string, 0, len(specifiers))
+	for _, specifier := range specifiers {
+		texts = append(texts, specifier.Text)
+	}
+	return strings.Join(texts, ", ")
+}
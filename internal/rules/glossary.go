@@ -0,0 +1,106 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/phillippbertram/xc-strings/internal/glossary"
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+// GlossaryRule reports translations which do not use the approved translation of a glossary term
+// contained in the base value, or which use forbidden terms
+type GlossaryRule struct{}
+
+func init() {
+	Register(GlossaryRule{})
+}
+
+func (GlossaryRule) ID() string { return "glossary" }
+func (GlossaryRule) Description() string {
+	return "Translations must use the approved terms of the glossary"
+}
+func (GlossaryRule) DefaultSeverity() Severity { return SeverityWarning }
+func (GlossaryRule) OptIn() bool               { return true }
+func (GlossaryRule) RequiresBase() bool        { return true }
+
+func (GlossaryRule) Check(ctx *Context) ([]Diagnostic, error) {
+	if ctx.Set == nil {
+		return nil, nil
+	}
+
+	g, err := loadGlossary(ctx)
+	if err != nil || g == nil {
+		return nil, err
+	}
+
+	var diagnostics []Diagnostic
+	report := func(file *localizable.StringsFile, line *localizable.Line, message string) {
+		diagnostics = append(diagnostics, Diagnostic{
+			Path:    file.Path,
+			Line:    line.LineNumber,
+			Column:  line.Column(),
+			Key:     line.Key,
+			Message: fmt.Sprintf("value of key `%s` %s", line.Key, message),
+		})
+	}
+
+	for _, table := range ctx.Set.Tables {
+		if table.Base() == nil {
+			continue
+		}
+
+		for _, locale := range table.Locales {
+			forbidden := g.ForbiddenTerms(locale.Code)
+			for i := range locale.File.Lines {
+				line := &locale.File.Lines[i]
+				if line.Key == "" {
+					continue
+				}
+				for _, term := range forbidden {
+					if glossary.ContainsWord(line.Value, term) {
+						report(locale.File, line, fmt.Sprintf("uses the forbidden term \"%s\"", term))
+					}
+				}
+			}
+		}
+	}
+
+	forEachTranslatedEntry(ctx, func(_ *localizable.Table, _, translation *localizable.Locale, _ string, baseEntry, entry *localizable.Line) {
+		for _, term := range g.Terms {
+			if !glossary.Contains(baseEntry.Value, term.Term) {
+				continue
+			}
+			approved := term.Approved(translation.Code)
+			switch {
+			case approved == "" || glossary.Contains(entry.Value, approved):
+			case term.Keep:
+				report(translation.File, entry, fmt.Sprintf("does not keep \"%s\" untranslated", term.Term))
+			default:
+				report(translation.File, entry, fmt.Sprintf("does not translate \"%s\" as \"%s\"", term.Term, approved))
+			}
+			for _, forbiddenTerm := range term.ForbiddenTerms(translation.Code) {
+				if glossary.ContainsWord(entry.Value, forbiddenTerm) {
+					report(translation.File, entry, fmt.Sprintf("translates \"%s\" as the forbidden term \"%s\"", term.Term, forbiddenTerm))
+				}
+			}
+		}
+	})
+	return diagnostics, nil
+}
+
+// loadGlossary loads the glossary of the configuration, or .xcstrings-glossary.yml next to the configuration
+// or in the root directory. It returns nil if there is no glossary.
+func loadGlossary(ctx *Context) (*glossary.Glossary, error) {
+	path := ctx.Config.GlossaryPath()
+	if path == "" {
+		path = filepath.Join(ctx.projectDir(), glossary.FileName)
+
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+	}
+	return glossary.Load(path)
+}
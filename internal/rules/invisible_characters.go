@@ -0,0 +1,69 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InvisibleCharactersRule reports zero-width characters and non-breaking spaces in values,
+// which are usually pasted by accident. Non-breaking spaces in front of :;?!» and after « are French typography.
+type InvisibleCharactersRule struct{}
+
+var invisibleCharacters = map[rune]string{
+	'\u200B': "zero-width space",
+	'\u200C': "zero-width non-joiner",
+	'\u200D': "zero-width joiner",
+	'\u2060': "word joiner",
+	'\uFEFF': "zero-width no-break space",
+	'\u00A0': "non-breaking space",
+	'\u202F': "narrow non-breaking space",
+}
+
+func init() {
+	Register(InvisibleCharactersRule{})
+}
+
+func (InvisibleCharactersRule) ID() string { return "invisibleCharacters" }
+func (InvisibleCharactersRule) Description() string {
+	return "Values must not contain zero-width characters or stray non-breaking spaces"
+}
+func (InvisibleCharactersRule) DefaultSeverity() Severity { return SeverityWarning }
+func (InvisibleCharactersRule) OptIn() bool               { return true }
+
+func (InvisibleCharactersRule) Check(ctx *Context) ([]Diagnostic, error) {
+	var diagnostics []Diagnostic
+	for _, file := range ctx.Files {
+		for _, line := range file.Lines {
+			if line.Key == "" {
+				continue
+			}
+
+			runes := []rune(line.RawValue())
+			for i, r := range runes {
+				name, ok := invisibleCharacters[r]
+				if !ok || isTypographicSpace(runes, i) {
+					continue
+				}
+				diagnostics = append(diagnostics, Diagnostic{
+					Path:    file.Path,
+					Line:    line.LineNumber,
+					Column:  line.Column(),
+					Key:     line.Key,
+					Message: fmt.Sprintf("value of key `%s` contains a %s (U+%04X) at position %d", line.Key, name, r, i+1),
+				})
+			}
+		}
+	}
+	return diagnostics, nil
+}
+
+// isTypographicSpace reports whether the non-breaking space at index i is required by French typography
+func isTypographicSpace(runes []rune, i int) bool {
+	if runes[i] != '\u00A0' && runes[i] != '\u202F' {
+		return false
+	}
+	if i+1 < len(runes) && strings.ContainsRune(":;?!»", runes[i+1]) {
+		return true
+	}
+	return i > 0 && runes[i-1] == '«'
+}
@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/phillippbertram/xc-strings/internal"
+)
+
+// LanguagesRule compares the languages declared in the Xcode project or String Catalogs
+// with the .lproj directories on disk.
+type LanguagesRule struct{}
+
+func init() {
+	Register(LanguagesRule{})
+}
+
+func (LanguagesRule) ID() string { return "languages" }
+func (LanguagesRule) Description() string {
+	return "Declared languages must match the .lproj directories and tables on disk"
+}
+func (LanguagesRule) DefaultSeverity() Severity { return SeverityError }
+func (LanguagesRule) OptIn() bool               { return true }
+func (LanguagesRule) ProjectScoped() bool       { return true }
+
+func (LanguagesRule) Check(ctx *Context) ([]Diagnostic, error) {
+	report, err := CheckLanguages(ctx.Root, ctx.IgnorePatterns)
+	if errors.Is(err, internal.ErrNoDeclaredLanguages) {
+		// nothing to compare with
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// declared languages are reported at the first source, all sources are listed in the message
+	source := report.Declared.Sources[0]
+	var declaredIn string
+	if len(report.Declared.Sources) > 1 {
+		declaredIn = fmt.Sprintf(" (declared in %s)", strings.Join(report.Declared.Sources, ", "))
+	}
+
+	var diagnostics []Diagnostic
+	for _, language := range report.DeclaredWithoutFiles {
+		diagnostics = append(diagnostics, Diagnostic{
+			Path:    source,
+			Message: fmt.Sprintf("language '%s' is declared but has no .lproj directory%s", language, declaredIn),
+		})
+	}
+	for _, directory := range report.Undeclared {
+		diagnostics = append(diagnostics, Diagnostic{
+			Path:    directory.Path,
+			Message: fmt.Sprintf("language '%s' is not declared in the project", directory.Language),
+		})
+	}
+	for path, tables := range report.MissingTables {
+		for _, table := range tables {
+			diagnostics = append(diagnostics, Diagnostic{
+				Path:    path,
+				Message: fmt.Sprintf("table '%s' is missing", table),
+			})
+		}
+	}
+	return diagnostics, nil
+}
+
+// CheckLanguages compares the languages declared by the project with the .lproj directories below root
+func CheckLanguages(root string, ignorePatterns []string) (*internal.LanguageReport, error) {
+	declared, err := internal.FindDeclaredLanguages(".", root, ignorePatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	directories, err := internal.FindLocalizationDirectories(root, ignorePatterns)
+	if err != nil {
+		return nil, fmt.Errorf("error finding .lproj directories: %w", err)
+	}
+
+	return internal.CompareLanguages(declared, directories), nil
+}
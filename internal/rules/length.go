@@ -0,0 +1,135 @@
+package rules
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+// LengthRule reports values which are too long for the UI: translations longer than a ratio of the base value,
+// and values exceeding the limit of their key. Lengths are counted in characters as users see them.
+type LengthRule struct{}
+
+// LengthOptions are the options of the length rule in .xcstrings.yml:
+//
+//	length:
+//	  ratio: 1.5     # translations may be at most 150% as long as the base value, 0 disables the check
+//	  minLength: 10  # translations up to this length are not compared with the base value, like "OK" and "Okay"
+//	  limits:
+//	    "tab_*": 12  # glob patterns for keys, or a single key
+//	    button_save: 10
+//
+// Limits can also be declared in the strings files with a /* max-length: 20 */ comment above the entry.
+type LengthOptions struct {
+	Ratio     *float64       `yaml:"ratio"`
+	MinLength *int           `yaml:"minLength"`
+	Limits    map[string]int `yaml:"limits"`
+}
+
+const (
+	defaultLengthRatio     = 1.5
+	defaultLengthMinLength = 10
+)
+
+func init() {
+	Register(LengthRule{})
+}
+
+func (LengthRule) ID() string { return "length" }
+func (LengthRule) Description() string {
+	return "Values must not exceed the length budget of their key"
+}
+func (LengthRule) DefaultSeverity() Severity { return SeverityWarning }
+func (LengthRule) OptIn() bool               { return true }
+func (LengthRule) RequiresBase() bool        { return true }
+
+func (r LengthRule) Check(ctx *Context) ([]Diagnostic, error) {
+	if ctx.Set == nil {
+		return nil, nil
+	}
+
+	var options LengthOptions
+	if err := ctx.RuleOptions(r.ID(), &options); err != nil {
+		return nil, err
+	}
+	ratio := defaultLengthRatio
+	if options.Ratio != nil {
+		ratio = *options.Ratio
+	}
+	minLength := defaultLengthMinLength
+	if options.MinLength != nil {
+		minLength = *options.MinLength
+	}
+
+	var diagnostics []Diagnostic
+	report := func(file *localizable.StringsFile, line *localizable.Line, message string) {
+		diagnostics = append(diagnostics, Diagnostic{
+			Path:    file.Path,
+			Line:    line.LineNumber,
+			Column:  line.Column(),
+			Key:     line.Key,
+			Message: fmt.Sprintf("value of key `%s` has %d characters, %s", line.Key, line.Length(), message),
+		})
+	}
+
+	// lines exceeding their limit are not compared with the base value
+	exceeded := make(map[*localizable.Line]bool)
+	for _, table := range ctx.Set.Tables {
+		var baseLimits map[string]int
+		if base := table.Base(); base != nil {
+			baseLimits = base.File.MaxLengths()
+		}
+
+		for _, locale := range table.Locales {
+			limits := locale.File.MaxLengths()
+			for i := range locale.File.Lines {
+				line := &locale.File.Lines[i]
+				if line.Key == "" {
+					continue
+				}
+				if limit := options.limit(line.Key, limits, baseLimits); limit > 0 && line.Length() > limit {
+					report(locale.File, line, fmt.Sprintf("at most %d are allowed", limit))
+					exceeded[line] = true
+				}
+			}
+		}
+	}
+
+	if ratio <= 0 {
+		return diagnostics, nil
+	}
+	forEachTranslatedEntry(ctx, func(_ *localizable.Table, _, translation *localizable.Locale, _ string, baseEntry, entry *localizable.Line) {
+		length := entry.Length()
+		if exceeded[entry] || length <= minLength {
+			return
+		}
+		baseLength := baseEntry.Length()
+		if baseLength > 0 && float64(length) > float64(baseLength)*ratio {
+			report(translation.File, entry, fmt.Sprintf("%d%% of the base value (%d), at most %.0f%% are allowed", length*100/baseLength, baseLength, ratio*100))
+		}
+	})
+	return diagnostics, nil
+}
+
+// limit returns the maximum length of a key. Comments in the file take precedence over comments in the base file
+// and the configuration, where a key takes precedence over the longest matching pattern.
+func (o LengthOptions) limit(key string, limits map[string]int, baseLimits map[string]int) int {
+	if limit, ok := limits[key]; ok {
+		return limit
+	}
+	if limit, ok := baseLimits[key]; ok {
+		return limit
+	}
+	if limit, ok := o.Limits[key]; ok {
+		return limit
+	}
+
+	limit, longest := 0, -1
+	for pattern, patternLimit := range o.Limits {
+		if matched, _ := path.Match(pattern, key); matched && len(pattern) > longest {
+			limit, longest = patternLimit, len(pattern)
+		}
+	}
+	return limit
+}
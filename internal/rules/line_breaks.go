@@ -0,0 +1,42 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+// LineBreaksRule reports translations with another number of \n line breaks than the base value
+type LineBreaksRule struct{}
+
+func init() {
+	Register(LineBreaksRule{})
+}
+
+func (LineBreaksRule) ID() string { return "lineBreaks" }
+func (LineBreaksRule) Description() string {
+	return "Translations must have as many line breaks as the base value"
+}
+func (LineBreaksRule) DefaultSeverity() Severity { return SeverityWarning }
+func (LineBreaksRule) OptIn() bool               { return true }
+func (LineBreaksRule) RequiresBase() bool        { return true }
+
+func (LineBreaksRule) Check(ctx *Context) ([]Diagnostic, error) {
+	var diagnostics []Diagnostic
+	forEachTranslatedEntry(ctx, func(_ *localizable.Table, _, translation *localizable.Locale, key string, baseEntry, entry *localizable.Line) {
+		baseCount := strings.Count(baseEntry.RawValue(), `\n`)
+		count := strings.Count(entry.RawValue(), `\n`)
+		if count == baseCount {
+			return
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Path:    translation.File.Path,
+			Line:    entry.LineNumber,
+			Column:  entry.Column(),
+			Key:     key,
+			Message: fmt.Sprintf("value of key `%s` has %d line breaks, the base value %d", key, count, baseCount),
+		})
+	})
+	return diagnostics, nil
+}
@@ -0,0 +1,146 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+// MarkupRule reports broken HTML, Markdown and inflection markup, and translations whose markup differs from the base value.
+// Broken markup is rendered as raw text by AttributedString.
+type MarkupRule struct{}
+
+func init() {
+	Register(MarkupRule{})
+}
+
+func (MarkupRule) ID() string { return "markup" }
+func (MarkupRule) Description() string {
+	return "HTML, Markdown and inflection markup must be balanced and match the base value"
+}
+func (MarkupRule) DefaultSeverity() Severity { return SeverityWarning }
+func (MarkupRule) OptIn() bool               { return true }
+func (MarkupRule) RequiresBase() bool        { return true }
+
+func (MarkupRule) Check(ctx *Context) ([]Diagnostic, error) {
+	if ctx.Set == nil {
+		return nil, nil
+	}
+
+	var diagnostics []Diagnostic
+	report := func(file *localizable.StringsFile, line *localizable.Line, messages []string) {
+		for _, message := range messages {
+			diagnostics = append(diagnostics, Diagnostic{
+				Path:    file.Path,
+				Line:    line.LineNumber,
+				Column:  line.Column(),
+				Key:     line.Key,
+				Message: fmt.Sprintf("key `%s`: %s", line.Key, message),
+			})
+		}
+	}
+
+	for _, table := range ctx.Set.Tables {
+		base := table.Base()
+		if base == nil {
+			continue
+		}
+		for _, baseEntry := range base.Entries() {
+			report(base.File, baseEntry, unbalancedMarkup(localizable.ParseMarkup(baseEntry.Value)))
+		}
+	}
+
+	forEachTranslatedEntry(ctx, func(_ *localizable.Table, _, translation *localizable.Locale, _ string, baseEntry, entry *localizable.Line) {
+		markup := localizable.ParseMarkup(entry.Value)
+		report(translation.File, entry, unbalancedMarkup(markup))
+		report(translation.File, entry, compareMarkup(localizable.ParseMarkup(baseEntry.Value), markup))
+	})
+	return diagnostics, nil
+}
+
+// unbalancedMarkup describes unclosed HTML tags and unpaired Markdown markers
+func unbalancedMarkup(markup localizable.Markup) []string {
+	var messages []string
+	for _, tag := range markup.UnbalancedTags {
+		messages = append(messages, fmt.Sprintf("unbalanced tag <%s>", tag))
+	}
+	for _, marker := range sortedKeys(markup.EmphasisMarkers) {
+		if markup.EmphasisMarkers[marker]%2 != 0 {
+			messages = append(messages, fmt.Sprintf("unbalanced Markdown marker %s", marker))
+		}
+	}
+	return messages
+}
+
+// compareMarkup describes the differences of the markup of a translation to the base value
+func compareMarkup(base localizable.Markup, translation localizable.Markup) []string {
+	var messages []string
+
+	missingTags, extraTags := difference(base.Tags, translation.Tags)
+	for _, tag := range missingTags {
+		messages = append(messages, fmt.Sprintf("missing tag <%s>", tag))
+	}
+	for _, tag := range extraTags {
+		messages = append(messages, fmt.Sprintf("extra tag <%s>", tag))
+	}
+
+	missingLinks, extraLinks := difference(base.Links, translation.Links)
+	for i, url := range missingLinks {
+		if i < len(extraLinks) {
+			messages = append(messages, fmt.Sprintf("link URL changed from %s to %s", url, extraLinks[i]))
+		} else {
+			messages = append(messages, fmt.Sprintf("dropped link to %s", url))
+		}
+	}
+	for _, url := range extraLinks[min(len(missingLinks), len(extraLinks)):] {
+		messages = append(messages, fmt.Sprintf("extra link to %s", url))
+	}
+
+	if translation.Inflections < base.Inflections {
+		messages = append(messages, fmt.Sprintf("missing inflection markup, the base value has %d ^[...](inflect: true)", base.Inflections))
+	}
+
+	for _, marker := range sortedKeys(base.EmphasisMarkers) {
+		if translation.EmphasisMarkers[marker] < base.EmphasisMarkers[marker] {
+			messages = append(messages, fmt.Sprintf("missing Markdown marker %s", marker))
+		}
+	}
+
+	return messages
+}
+
+// difference returns the values of a missing in b and the values of b missing in a, respecting duplicates
+func difference(a []string, b []string) ([]string, []string) {
+	counts := make(map[string]int)
+	for _, value := range b {
+		counts[value]++
+	}
+
+	var missing []string
+	for _, value := range a {
+		if counts[value] > 0 {
+			counts[value]--
+		} else {
+			missing = append(missing, value)
+		}
+	}
+
+	var extra []string
+	for _, value := range b {
+		if counts[value] > 0 {
+			counts[value]--
+			extra = append(extra, value)
+		}
+	}
+	return missing, extra
+}
+
+func sortedKeys(values map[string]int) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,55 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/phillippbertram/xc-strings/internal"
+)
+
+// ModuleBundleRule reports lookups in Swift package targets which do not pass `bundle: .module`
+// although the key is only bundled with the package.
+type ModuleBundleRule struct{}
+
+func init() {
+	Register(ModuleBundleRule{})
+}
+
+func (ModuleBundleRule) ID() string { return "moduleBundle" }
+func (ModuleBundleRule) Description() string {
+	return "Keys of Swift packages must be looked up with `bundle: .module`"
+}
+func (ModuleBundleRule) DefaultSeverity() Severity { return SeverityError }
+func (ModuleBundleRule) OptIn() bool               { return true }
+func (ModuleBundleRule) RequiresBase() bool        { return true }
+
+func (ModuleBundleRule) Check(ctx *Context) ([]Diagnostic, error) {
+	if ctx.Target == nil || !ctx.Target.UsesModuleBundle || ctx.Set == nil {
+		return nil, nil
+	}
+
+	usages, err := internal.FindKeyUsages(ctx.Target.SourcePaths, ctx.IgnorePatterns, ctx.UsagePatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]struct{}{}
+	for _, file := range ctx.Set.BaseFiles() {
+		for _, key := range file.GetAllKeys() {
+			keys[key] = struct{}{}
+		}
+	}
+
+	var diagnostics []Diagnostic
+	for _, usage := range usages {
+		if _, ok := keys[usage.Key]; ok && !usage.UsesModuleBundle() {
+			diagnostics = append(diagnostics, Diagnostic{
+				Path:    usage.Path,
+				Line:    usage.Line,
+				Column:  usage.Column,
+				Key:     usage.Key,
+				Message: fmt.Sprintf("key `%s` is looked up without `bundle: .module`", usage.Key),
+			})
+		}
+	}
+	return diagnostics, nil
+}
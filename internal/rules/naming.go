@@ -0,0 +1,245 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/phillippbertram/xc-strings/internal"
+	"github.com/phillippbertram/xc-strings/internal/configfile"
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+// NamingRule reports keys which do not follow the naming convention of the project.
+// The rule fails if no style, pattern, prefixes or maxDepth is configured, since there is no convention to check.
+type NamingRule struct{}
+
+// NamingOptions are the options of the naming rule in .xcstrings.yml:
+//
+//	naming:
+//	  style: lowerCamel   # snake_case (settings_screen_title), dot (settings.screen.title) or lowerCamel (settingsScreen_title)
+//	  separator: "_"      # separator between the segments of a key, defaults to the separator of the style
+//	  maxDepth: 3         # maximum number of segments
+//	  prefixes: [common, settings]
+//	  pattern: '^[a-z]+(_[a-z]+)*$' # regular expression replacing style and separator
+type NamingOptions struct {
+	Style     string   `yaml:"style"`
+	Separator string   `yaml:"separator"`
+	MaxDepth  int      `yaml:"maxDepth"`
+	Prefixes  []string `yaml:"prefixes"`
+	Pattern   string   `yaml:"pattern"`
+}
+
+const (
+	NamingStyleSnakeCase  = "snake_case"
+	NamingStyleDot        = "dot"
+	NamingStyleLowerCamel = "lowerCamel"
+)
+
+var (
+	snakeCaseSegmentRegex  = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+	lowerCamelSegmentRegex = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+
+	// splits segments into words at separators and camel case boundaries
+	wordSeparatorRegex = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+	camelBoundaryRegex = regexp.MustCompile(`(\p{Ll}|\p{N})(\p{Lu})|(\p{Lu})(\p{Lu}\p{Ll})`)
+)
+
+func init() {
+	Register(NamingRule{})
+}
+
+func (NamingRule) ID() string                { return "naming" }
+func (NamingRule) Description() string       { return "Keys must follow the naming convention" }
+func (NamingRule) DefaultSeverity() Severity { return SeverityWarning }
+func (NamingRule) OptIn() bool               { return true }
+
+func (r NamingRule) Check(ctx *Context) ([]Diagnostic, error) {
+	var options NamingOptions
+	if err := ctx.RuleOptions(r.ID(), &options); err != nil {
+		return nil, err
+	}
+	if options.Style == "" && options.Pattern == "" && len(options.Prefixes) == 0 && options.MaxDepth == 0 {
+		return nil, fmt.Errorf("no naming convention configured, set style, pattern, prefixes or maxDepth of the %s rule in %s", r.ID(), configfile.FileName)
+	}
+
+	convention, err := newNamingConvention(options)
+	if err != nil {
+		return nil, err
+	}
+
+	var diagnostics []Diagnostic
+	for _, file := range keyDefiningFiles(ctx) {
+		for _, line := range file.Lines {
+			if line.Key == "" {
+				continue
+			}
+
+			problem := convention.check(line.Key)
+			if problem == "" {
+				continue
+			}
+
+			message := fmt.Sprintf("key `%s` %s", line.Key, problem)
+			if suggestion := convention.normalize(line.Key); suggestion != line.Key {
+				message += fmt.Sprintf(", use `%s`", suggestion)
+			}
+			diagnostics = append(diagnostics, Diagnostic{
+				Path:    file.Path,
+				Line:    line.LineNumber,
+				Column:  line.Column(),
+				Key:     line.Key,
+				Message: message,
+			})
+		}
+	}
+	return diagnostics, nil
+}
+
+// keyDefiningFiles returns one file per table, preferably the base file, so that keys are reported once instead of in every locale
+func keyDefiningFiles(ctx *Context) []*localizable.StringsFile {
+	if ctx.Set == nil {
+		return ctx.Files
+	}
+
+	var files []*localizable.StringsFile
+	for _, table := range ctx.Set.Tables {
+		if base := table.Base(); base != nil {
+			files = append(files, base.File)
+		} else if len(table.Locales) > 0 {
+			files = append(files, table.Locales[0].File)
+		}
+	}
+	return files
+}
+
+type namingConvention struct {
+	options      NamingOptions
+	separator    string
+	segmentRegex *regexp.Regexp
+	pattern      *regexp.Regexp
+}
+
+func newNamingConvention(options NamingOptions) (*namingConvention, error) {
+	convention := &namingConvention{options: options, separator: options.Separator}
+
+	switch options.Style {
+	case NamingStyleSnakeCase:
+		convention.segmentRegex = snakeCaseSegmentRegex
+		if convention.separator == "" {
+			convention.separator = "_"
+		}
+	case NamingStyleDot:
+		convention.segmentRegex = lowerCamelSegmentRegex
+		if convention.separator == "" {
+			convention.separator = "."
+		}
+	case NamingStyleLowerCamel, "":
+		convention.segmentRegex = lowerCamelSegmentRegex
+		if convention.separator == "" {
+			convention.separator = "_"
+		}
+	default:
+		return nil, fmt.Errorf("unknown naming style '%s' (%s, %s, %s)", options.Style, NamingStyleSnakeCase, NamingStyleDot, NamingStyleLowerCamel)
+	}
+
+	if options.Pattern != "" {
+		pattern, err := regexp.Compile(options.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid naming pattern '%s': %w", options.Pattern, err)
+		}
+		convention.pattern = pattern
+	}
+
+	return convention, nil
+}
+
+// check returns a description of the first violation of the convention, or an empty string
+func (c *namingConvention) check(key string) string {
+	if c.pattern != nil && !c.pattern.MatchString(key) {
+		return fmt.Sprintf("does not match the pattern `%s`", c.pattern)
+	}
+
+	separator := c.separatorFor(key)
+	segments := strings.Split(key, separator)
+	if c.pattern == nil && c.options.Style != "" {
+		for _, segment := range segments {
+			if !c.segmentRegex.MatchString(segment) {
+				return fmt.Sprintf("is not %s with segments separated by `%s`", c.styleName(), separator)
+			}
+		}
+	}
+
+	if c.options.MaxDepth > 0 && len(segments) > c.options.MaxDepth {
+		return fmt.Sprintf("has %d segments, at most %d are allowed", len(segments), c.options.MaxDepth)
+	}
+
+	if len(c.options.Prefixes) > 0 && !internal.Contains(c.options.Prefixes, segments[0]) {
+		return fmt.Sprintf("does not start with an allowed prefix (%s)", strings.Join(c.options.Prefixes, ", "))
+	}
+
+	return ""
+}
+
+func (c *namingConvention) styleName() string {
+	if c.options.Style == NamingStyleSnakeCase {
+		return "snake_case"
+	}
+	return "lowerCamelCase"
+}
+
+// normalize converts a key into the style of the convention. Separators in the key become segment separators,
+// except for snake_case where every word is a segment. Keys are kept without a configured style.
+func (c *namingConvention) normalize(key string) string {
+	if c.options.Style == "" {
+		return key
+	}
+
+	var segments []string
+	for _, segment := range wordSeparatorRegex.Split(key, -1) {
+		words := splitWords(segment)
+		if len(words) == 0 {
+			continue
+		}
+
+		if c.options.Style == NamingStyleSnakeCase {
+			for _, word := range words {
+				segments = append(segments, strings.ToLower(word))
+			}
+			continue
+		}
+
+		var sb strings.Builder
+		for i, word := range words {
+			word = strings.ToLower(word)
+			if i > 0 {
+				runes := []rune(word)
+				runes[0] = unicode.ToUpper(runes[0])
+				word = string(runes)
+			}
+			sb.WriteString(word)
+		}
+		segments = append(segments, sb.String())
+	}
+	return strings.Join(segments, c.separatorFor(key))
+}
+
+// separatorFor returns the separator of the segments. Without a configured style or separator,
+// keys may use dots instead of underscores like `xcs sort` supports it.
+func (c *namingConvention) separatorFor(key string) string {
+	if c.options.Style == "" && c.options.Separator == "" && strings.Contains(key, ".") && !strings.Contains(key, "_") {
+		return "."
+	}
+	return c.separator
+}
+
+// splitWords splits a segment like "settingsScreenURL" into its words
+func splitWords(segment string) []string {
+	if segment == "" {
+		return nil
+	}
+	spaced := camelBoundaryRegex.ReplaceAllString(segment, "${1}${3} ${2}${4}")
+	spaced = camelBoundaryRegex.ReplaceAllString(spaced, "${1}${3} ${2}${4}")
+	return strings.Fields(spaced)
+}
@@ -0,0 +1,92 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+// NearDuplicatesRule reports keys which differ only by case, whitespace, Unicode normalization or look-alike characters,
+// like `Settings_title` next to `settings_title`, within a table and across the tables of a bundle
+type NearDuplicatesRule struct{}
+
+func init() {
+	Register(NearDuplicatesRule{})
+}
+
+func (NearDuplicatesRule) ID() string { return "nearDuplicates" }
+func (NearDuplicatesRule) Description() string {
+	return "Keys must not differ only by case, whitespace, Unicode normalization or look-alike characters"
+}
+func (NearDuplicatesRule) DefaultSeverity() Severity { return SeverityWarning }
+func (NearDuplicatesRule) OptIn() bool               { return true }
+
+func (NearDuplicatesRule) Check(ctx *Context) ([]Diagnostic, error) {
+	type definition struct {
+		file *localizable.StringsFile
+		line localizable.Line
+	}
+
+	var diagnostics []Diagnostic
+	report := func(file *localizable.StringsFile, line localizable.Line, other string, location string) {
+		key := line.RawKey()
+		differences := localizable.KeyDifferences(key, other)
+		diagnostics = append(diagnostics, Diagnostic{
+			Path:    file.Path,
+			Line:    line.LineNumber,
+			Column:  line.Column(),
+			Key:     line.Key,
+			Message: fmt.Sprintf("key `%s` differs from `%s` %s only by %s", key, other, location, strings.Join(differences, " and ")),
+		})
+	}
+
+	// near duplicates within a file, in the base and in every translation
+	for _, file := range ctx.Files {
+		for _, lines := range file.FindNearDuplicateKeys() {
+			for _, line := range lines[1:] {
+				report(file, line, lines[0].RawKey(), fmt.Sprintf("at line %d", lines[0].LineNumber))
+			}
+		}
+	}
+
+	// near duplicates across the tables of a bundle, compared by one file per table, since the locales of a table
+	// share their keys. Tables of separate bundles like Swift packages are not compared.
+	// The first definition of each skeleton is kept.
+	definitions := make(map[string]map[string]definition) // by bundle directory and skeleton
+	for _, file := range keyDefiningFiles(ctx) {
+		dir := bundleDir(file)
+		if definitions[dir] == nil {
+			definitions[dir] = make(map[string]definition)
+		}
+		reported := make(map[string]bool)
+		for _, line := range file.Lines {
+			if line.Key == "" || reported[line.RawKey()] {
+				continue
+			}
+			reported[line.RawKey()] = true
+
+			skeleton := localizable.KeySkeleton(line.RawKey())
+			first, ok := definitions[dir][skeleton]
+			switch {
+			case !ok:
+				definitions[dir][skeleton] = definition{file: file, line: line}
+			case first.file != file && first.line.RawKey() != line.RawKey():
+				// the same key in several tables is not a near duplicate
+				report(file, line, first.line.RawKey(), "in "+first.file.Path)
+			}
+		}
+	}
+
+	return diagnostics, nil
+}
+
+// bundleDir returns the directory containing the .lproj directory of the file, or the directory of the file
+func bundleDir(file *localizable.StringsFile) string {
+	dir := filepath.Dir(file.Path)
+	if file.Locale() != "" {
+		dir = filepath.Dir(dir)
+	}
+	return filepath.Clean(dir)
+}
@@ -0,0 +1,40 @@
+package rules
+
+import "fmt"
+
+// OrphansRule reports keys of translations which are not defined in the base file of their table
+type OrphansRule struct{}
+
+func init() {
+	Register(OrphansRule{})
+}
+
+func (OrphansRule) ID() string { return "orphans" }
+func (OrphansRule) Description() string {
+	return "Keys of translations must be defined in the base file"
+}
+func (OrphansRule) DefaultSeverity() Severity { return SeverityWarning }
+func (OrphansRule) OptIn() bool               { return true }
+func (OrphansRule) RequiresBase() bool        { return true }
+
+func (OrphansRule) Check(ctx *Context) ([]Diagnostic, error) {
+	if ctx.Set == nil {
+		return nil, nil
+	}
+
+	var diagnostics []Diagnostic
+	for _, table := range ctx.Set.Tables {
+		for _, translation := range table.Translations() {
+			for _, line := range table.Orphans(translation) {
+				diagnostics = append(diagnostics, Diagnostic{
+					Path:    translation.File.Path,
+					Line:    line.LineNumber,
+					Column:  line.Column(),
+					Key:     line.Key,
+					Message: fmt.Sprintf("key `%s` is not defined in the base file %s", line.Key, table.Base().File.Path),
+				})
+			}
+		}
+	}
+	return diagnostics, nil
+}
@@ -0,0 +1,83 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+// PunctuationRule reports translations which end with another kind of punctuation than the base value
+type PunctuationRule struct{}
+
+// terminal punctuation by kind with its article for messages, including full-width and language specific variants
+var punctuationKinds = []struct {
+	name       string
+	characters string
+}{
+	{"an ellipsis", "…"},
+	{"a colon", ":："},
+	{"a question mark", "?？\u037E"}, // U+037E is the Greek question mark
+	{"an exclamation mark", "!！"},
+	{"a period", ".。।"},
+}
+
+func init() {
+	Register(PunctuationRule{})
+}
+
+func (PunctuationRule) ID() string { return "punctuation" }
+func (PunctuationRule) Description() string {
+	return "Translations must end with the same kind of punctuation as the base value"
+}
+func (PunctuationRule) DefaultSeverity() Severity { return SeverityWarning }
+func (PunctuationRule) OptIn() bool               { return true }
+func (PunctuationRule) RequiresBase() bool        { return true }
+
+func (PunctuationRule) Check(ctx *Context) ([]Diagnostic, error) {
+	var diagnostics []Diagnostic
+	forEachTranslatedEntry(ctx, func(_ *localizable.Table, _, translation *localizable.Locale, key string, baseEntry, entry *localizable.Line) {
+		baseKind := terminalPunctuation(baseEntry.RawValue())
+		kind := terminalPunctuation(entry.RawValue())
+		if kind == baseKind {
+			return
+		}
+
+		message := fmt.Sprintf("value of key `%s` ends with %s, the base value with %s", key, kind, baseKind)
+		switch {
+		case baseKind == "":
+			message = fmt.Sprintf("value of key `%s` ends with %s, the base value without punctuation", key, kind)
+		case kind == "":
+			message = fmt.Sprintf("value of key `%s` does not end with %s like the base value", key, baseKind)
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Path:    translation.File.Path,
+			Line:    entry.LineNumber,
+			Column:  entry.Column(),
+			Key:     key,
+			Message: message,
+		})
+	})
+	return diagnostics, nil
+}
+
+// terminalPunctuation returns the kind of punctuation the value ends with, or an empty string
+func terminalPunctuation(value string) string {
+	value = strings.TrimRightFunc(value, func(r rune) bool {
+		// closing quotes and brackets may follow the punctuation
+		return unicode.IsSpace(r) || strings.ContainsRune(`"'”’»)]`, r)
+	})
+	if strings.HasSuffix(value, "...") {
+		return "an ellipsis"
+	}
+
+	for _, kind := range punctuationKinds {
+		for _, character := range kind.characters {
+			if strings.HasSuffix(value, string(character)) {
+				return kind.name
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/phillippbertram/xc-strings/internal"
+	"github.com/phillippbertram/xc-strings/internal/configfile"
+)
+
+var registry = map[string]Rule{}
+
+// Register adds a rule to the registry. Rules register themselves in an init function.
+func Register(rule Rule) {
+	if _, ok := registry[rule.ID()]; ok {
+		panic(fmt.Sprintf("rule %s registered twice", rule.ID()))
+	}
+	registry[rule.ID()] = rule
+}
+
+// Get returns the rule with the given ID
+func Get(id string) (Rule, bool) {
+	rule, ok := registry[id]
+	return rule, ok
+}
+
+// All returns all registered rules sorted by ID
+func All() []Rule {
+	rules := make([]Rule, 0, len(registry))
+	for _, rule := range registry {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID() < rules[j].ID()
+	})
+	return rules
+}
+
+// IDs returns the IDs of all registered rules sorted alphabetically
+func IDs() []string {
+	var ids []string
+	for _, rule := range All() {
+		ids = append(ids, rule.ID())
+	}
+	return ids
+}
+
+// Select returns the rules to run. If include is given, only these rules are selected,
+// otherwise the default rules and the opt-in rules enabled in the project configuration, except the excluded ones.
+func Select(include []string, exclude []string, config *configfile.Config) ([]Rule, error) {
+	if len(include) > 0 && len(exclude) > 0 {
+		return nil, fmt.Errorf("you cannot use both --include and --exclude flags at the same time")
+	}
+
+	for _, id := range append(include, exclude...) {
+		if _, ok := registry[id]; !ok {
+			return nil, fmt.Errorf("unknown rule: %s (available: %v)", id, IDs())
+		}
+	}
+
+	if len(include) > 0 {
+		var selected []Rule
+		for _, rule := range All() {
+			if internal.Contains(include, rule.ID()) {
+				selected = append(selected, rule)
+			}
+		}
+		return selected, nil
+	}
+
+	var selected []Rule
+	for _, rule := range All() {
+		if internal.Contains(exclude, rule.ID()) {
+			continue
+		}
+		// opt-in rules run if they are configured with a severity other than off
+		if _, configured := config.RuleConfig(rule.ID(), ""); !IsOptIn(rule) || configured && !config.IsRuleDisabled(rule.ID()) {
+			selected = append(selected, rule)
+		}
+	}
+	return selected, nil
+}
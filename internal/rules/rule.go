@@ -0,0 +1,236 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/phillippbertram/xc-strings/internal"
+	"github.com/phillippbertram/xc-strings/internal/configfile"
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// ParseSeverity parses a severity like "error", "warning" or "info"
+func ParseSeverity(value string) (Severity, error) {
+	switch severity := Severity(value); severity {
+	case SeverityError, SeverityWarning, SeverityInfo:
+		return severity, nil
+	default:
+		return "", fmt.Errorf("unknown severity '%s' (error, warning, info)", value)
+	}
+}
+
+// Diagnostic is a single issue found by a rule
+type Diagnostic struct {
+	RuleID   string
+	Severity Severity
+	Path     string
+	Line     int // 0 if the issue concerns the whole file
+	Column   int // 0 if the issue concerns the whole line
+	Key      string
+	Message  string
+}
+
+// Location formats the location of the diagnostic as path:line:column, which terminals and IDEs can open
+func (d Diagnostic) Location() string {
+	switch {
+	case d.Line > 0 && d.Column > 0:
+		return fmt.Sprintf("%s:%d:%d", d.Path, d.Line, d.Column)
+	case d.Line > 0:
+		return fmt.Sprintf("%s:%d", d.Path, d.Line)
+	default:
+		return d.Path
+	}
+}
+
+// Context holds everything a rule needs to check a set of strings files
+type Context struct {
+	Files          []*localizable.StringsFile
+	Set            *localizable.LocalizationSet
+	SourcePaths    []string         // paths of the Swift sources using the strings files
+	IgnorePatterns []string         // glob patterns for files or directories to ignore
+	Root           string           // directory in which .lproj directories and projects are searched
+	Target         *internal.Target // nil if the strings files are not checked per target
+	UsagePatterns  []*regexp.Regexp // additional patterns whose first group captures a key used in code
+	Config         *configfile.Config
+}
+
+// RuleOptions decodes the options of a rule from the project configuration into v.
+// v is left untouched if the rule has no options.
+func (ctx *Context) RuleOptions(ruleID string, v any) error {
+	config, ok := ctx.Config.RuleConfig(ruleID, "")
+	if !ok {
+		return nil
+	}
+	if err := config.Decode(v); err != nil {
+		return fmt.Errorf("invalid options for rule %s: %w", ruleID, err)
+	}
+	return nil
+}
+
+// projectDir returns the directory of the configuration file, or the root directory without configuration.
+// Project files like the glossary are looked up in this directory.
+func (ctx *Context) projectDir() string {
+	if ctx.Config != nil {
+		return ctx.Config.ResolvePath(".")
+	}
+	if ctx.Root == "" {
+		return "."
+	}
+	return ctx.Root
+}
+
+// filesByPath returns all strings files of the context by their cleaned path
+func (ctx *Context) filesByPath() map[string]*localizable.StringsFile {
+	files := make(map[string]*localizable.StringsFile)
+	for _, file := range ctx.Files {
+		files[filepath.Clean(file.Path)] = file
+	}
+	if ctx.Set != nil {
+		for _, table := range ctx.Set.Tables {
+			for _, locale := range table.Locales {
+				files[filepath.Clean(locale.File.Path)] = locale.File
+			}
+		}
+	}
+	return files
+}
+
+// Rule is a single check which can be enabled with `xcs check --include <id>`
+type Rule interface {
+	ID() string
+	Description() string
+	DefaultSeverity() Severity
+	Check(ctx *Context) ([]Diagnostic, error)
+}
+
+// projectScoped is implemented by rules which check the project as a whole
+type projectScoped interface {
+	ProjectScoped() bool
+}
+
+// IsProjectScoped reports whether the rule checks the whole project instead of the strings files of a target.
+// Project scoped rules run once, even if the strings files are checked per target.
+func IsProjectScoped(rule Rule) bool {
+	scoped, ok := rule.(projectScoped)
+	return ok && scoped.ProjectScoped()
+}
+
+// Run runs the given rules and returns their diagnostics sorted by location
+func Run(ctx *Context, rules []Rule) ([]Diagnostic, error) {
+	files := ctx.filesByPath()
+
+	var diagnostics []Diagnostic
+	for _, rule := range rules {
+		ruleDiagnostics, err := rule.Check(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error running rule %s: %w", rule.ID(), err)
+		}
+		for _, diagnostic := range ruleDiagnostics {
+			diagnostic.RuleID = rule.ID()
+			if diagnostic.Severity == "" {
+				diagnostic.Severity = rule.DefaultSeverity()
+			}
+
+			// suppression comments in the strings file disable the rule for single entries, ranges or the whole file
+			if file, ok := files[filepath.Clean(diagnostic.Path)]; ok && file.IsSuppressed(rule.ID(), diagnostic.Line) {
+				continue
+			}
+
+			// the project configuration may change the severity or turn the rule off for some paths
+			if ctx.isDisabledFor(rule.ID(), diagnostic.Path) {
+				continue
+			}
+			if config, ok := ctx.Config.RuleConfig(rule.ID(), diagnostic.Path); ok && config.Severity != "" && config.Severity != configfile.SeverityOff {
+				diagnostic.Severity = Severity(config.Severity)
+			}
+
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+
+	SortDiagnostics(diagnostics)
+	return diagnostics, nil
+}
+
+// isDisabledFor reports whether the project configuration turns the rule off for the path only.
+// Rules turned off globally only run if they are included explicitly, so they are not disabled here.
+func (ctx *Context) isDisabledFor(ruleID string, path string) bool {
+	config, ok := ctx.Config.RuleConfig(ruleID, path)
+	return ok && config.Severity == configfile.SeverityOff && !ctx.Config.IsRuleDisabled(ruleID)
+}
+
+// SortDiagnostics sorts diagnostics by path, line, column and rule
+func SortDiagnostics(diagnostics []Diagnostic) {
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i], diagnostics[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return a.RuleID < b.RuleID
+	})
+}
+
+// optIn is implemented by rules which do not run by default, because they enforce conventions
+// which are not shared by every project
+type optIn interface {
+	OptIn() bool
+}
+
+// IsOptIn reports whether the rule only runs if it is included with --include or configured in the project configuration
+func IsOptIn(rule Rule) bool {
+	o, ok := rule.(optIn)
+	return ok && o.OptIn()
+}
+
+// baseRequired is implemented by rules which compare the strings files with the base file of their table
+type baseRequired interface {
+	RequiresBase() bool
+}
+
+// RequiresBase reports whether the rule needs to know the base file of each table
+func RequiresBase(rule Rule) bool {
+	required, ok := rule.(baseRequired)
+	return ok && required.RequiresBase()
+}
+
+// forEachTranslatedEntry calls fn for each key of the base locale of each table which is defined in a translation,
+// ordered by translation and key. The entries are looked up by key once per locale.
+func forEachTranslatedEntry(ctx *Context, fn func(table *localizable.Table, base *localizable.Locale, translation *localizable.Locale, key string, baseEntry *localizable.Line, entry *localizable.Line)) {
+	if ctx.Set == nil {
+		return
+	}
+
+	for _, table := range ctx.Set.Tables {
+		base := table.Base()
+		if base == nil {
+			continue
+		}
+
+		keys := base.Keys()
+		baseEntries := base.Entries()
+		for _, translation := range table.Translations() {
+			entries := translation.Entries()
+			for _, key := range keys {
+				if entry, ok := entries[key]; ok {
+					fn(table, base, translation, key, baseEntries[key], entry)
+				}
+			}
+		}
+	}
+}
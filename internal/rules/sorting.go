@@ -0,0 +1,58 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+// SortingRule reports lines which are not sorted or sanitized like `xcs sort` would do it
+type SortingRule struct{}
+
+func init() {
+	Register(SortingRule{})
+}
+
+func (SortingRule) ID() string                { return "sorting" }
+func (SortingRule) Description() string       { return "Files must be sorted and sanitized" }
+func (SortingRule) DefaultSeverity() Severity { return SeverityWarning }
+func (SortingRule) fixPhase() int             { return fixPhaseReorder }
+
+func (SortingRule) Check(ctx *Context) ([]Diagnostic, error) {
+	var diagnostics []Diagnostic
+	for _, file := range ctx.Files {
+		for _, line := range file.UnsortedLines() {
+			diagnostics = append(diagnostics, Diagnostic{
+				Path:    file.Path,
+				Line:    line.LineNumber,
+				Column:  line.Column(),
+				Key:     line.Key,
+				Message: fmt.Sprintf("key `%s` is not sorted alphabetically", line.Key),
+			})
+		}
+		for _, line := range file.UnsanitizedLines() {
+			diagnostics = append(diagnostics, Diagnostic{
+				Path:    file.Path,
+				Line:    line.LineNumber,
+				Column:  1,
+				Key:     line.Key,
+				Message: fmt.Sprintf("line is not sanitized, expected `%s`", line.Sanitized()),
+			})
+		}
+	}
+	return diagnostics, nil
+}
+
+// Fix sorts and sanitizes the file like `xcs sort`. Files can only be excluded as a whole with xcs-disable-file.
+func (r SortingRule) Fix(ctx *Context, file *localizable.StringsFile) int {
+	if file.IsSuppressed(r.ID(), 0) {
+		return 0
+	}
+
+	count := len(file.UnsortedLines()) + len(file.UnsanitizedLines())
+	if count > 0 {
+		file.Sort()
+		file.Sanitize()
+	}
+	return count
+}
@@ -0,0 +1,243 @@
+package rules
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+	"github.com/phillippbertram/xc-strings/internal/spelling"
+)
+
+// SpellRule reports misspelled words in values, using Hunspell dictionaries of the project
+type SpellRule struct{}
+
+// SpellOptions are the options of the spell rule in .xcstrings.yml:
+//
+//	spell:
+//	  dictionaries: dictionaries # directory with Hunspell dictionaries like en_US.dic and en_US.aff
+//	  words: [Acme, SwiftUI]     # project words, also read from words.txt in the dictionaries directory
+//	  locales:
+//	    Base: en_US              # dictionary of a locale, detected from the locale code by default
+type SpellOptions struct {
+	Dictionaries string            `yaml:"dictionaries"`
+	Words        []string          `yaml:"words"`
+	Locales      map[string]string `yaml:"locales"`
+}
+
+const (
+	defaultDictionariesDir = "dictionaries"
+	wordListFileName       = "words.txt"
+)
+
+var (
+	spellWordRegex = regexp.MustCompile(`[\p{L}\p{M}\p{N}_]+(?:['’][\p{L}\p{M}\p{N}_]+)*`)
+
+	// markup, links and addresses are not spell checked
+	spellIgnoredRegex = regexp.MustCompile(`<[^<>]*>|\]\([^)]*\)|\b[a-zA-Z][a-zA-Z0-9+.-]*://\S+|\S+@\S+\.\S+`)
+)
+
+// dictionaries are cached by path, because they are used for the strings files of every target
+var dictionaries = map[string]*spelling.Dictionary{}
+
+func init() {
+	Register(SpellRule{})
+}
+
+func (SpellRule) ID() string                { return "spell" }
+func (SpellRule) Description() string       { return "Values must be spelled correctly" }
+func (SpellRule) DefaultSeverity() Severity { return SeverityWarning }
+func (SpellRule) OptIn() bool               { return true }
+
+func (r SpellRule) Check(ctx *Context) ([]Diagnostic, error) {
+	var options SpellOptions
+	if err := ctx.RuleOptions(r.ID(), &options); err != nil {
+		return nil, err
+	}
+
+	dir := options.Dictionaries
+	if dir == "" {
+		dir = defaultDictionariesDir
+	}
+	dir = filepath.Join(ctx.projectDir(), dir)
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	words, err := readWordList(filepath.Join(dir, wordListFileName))
+	if err != nil {
+		return nil, err
+	}
+	words = append(words, options.Words...)
+
+	var diagnostics []Diagnostic
+	for _, file := range ctx.Files {
+		dictionary, err := loadDictionary(dir, file.Locale(), options.Locales, words)
+		if err != nil {
+			return nil, err
+		}
+		if dictionary == nil {
+			continue
+		}
+
+		for _, line := range file.Lines {
+			if line.Key == "" {
+				continue
+			}
+
+			for _, word := range spellableWords(line.RawValue()) {
+				if checkSpelling(dictionary, word) {
+					continue
+				}
+
+				message := fmt.Sprintf("value of key `%s` contains the misspelled word `%s`", line.Key, word)
+				if suggestions := dictionary.Suggest(word); len(suggestions) > 0 {
+					message += fmt.Sprintf(", did you mean `%s`?", strings.Join(suggestions, "`, `"))
+				}
+				diagnostics = append(diagnostics, Diagnostic{
+					Path:    file.Path,
+					Line:    line.LineNumber,
+					Column:  wordColumn(line, word),
+					Key:     line.Key,
+					Message: message,
+				})
+			}
+		}
+	}
+	return diagnostics, nil
+}
+
+// loadDictionary returns the dictionary of a locale, or nil if the project has none
+func loadDictionary(dir string, locale string, locales map[string]string, words []string) (*spelling.Dictionary, error) {
+	path := findDictionary(dir, locale, locales)
+	if path == "" {
+		return nil, nil
+	}
+	if dictionary, ok := dictionaries[path]; ok {
+		return dictionary, nil
+	}
+
+	dictionary, err := spelling.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("error loading dictionary %s: %w", path, err)
+	}
+	for _, word := range words {
+		dictionary.AddWord(word)
+	}
+	dictionaries[path] = dictionary
+	return dictionary, nil
+}
+
+// findDictionary returns the .dic file for a locale like "pt-BR": the configured dictionary, pt_BR.dic, pt.dic
+// or the first dictionary of the language like pt_PT.dic
+func findDictionary(dir string, locale string, locales map[string]string) string {
+	if name, ok := locales[locale]; ok {
+		return filepath.Join(dir, strings.TrimSuffix(name, ".dic")+".dic")
+	}
+	if locale == "" || locale == localizable.BaseLocale {
+		return ""
+	}
+
+	code := strings.ReplaceAll(locale, "-", "_")
+	lang := language(locale)
+	for _, name := range []string{code, lang} {
+		path := filepath.Join(dir, name+".dic")
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(dir, lang+"_*.dic"))
+	if len(matches) > 0 {
+		return matches[0]
+	}
+	return ""
+}
+
+// readWordList reads a word list with one word per line. Lines starting with # are comments.
+func readWordList(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word != "" && !strings.HasPrefix(word, "#") {
+			words = append(words, word)
+		}
+	}
+	return words, scanner.Err()
+}
+
+// spellableWords returns the words of a value which are spell checked. Format specifiers, markup, links,
+// words with digits or underscores, acronyms and words like iPhone are skipped.
+func spellableWords(value string) []string {
+	value = localizable.Unescape(value)
+	for _, specifier := range localizable.ParseFormatSpecifiers(value) {
+		value = strings.Replace(value, specifier.Text, " ", 1)
+	}
+	value = spellIgnoredRegex.ReplaceAllString(value, " ")
+
+	var words []string
+	for _, word := range spellWordRegex.FindAllString(value, -1) {
+		if utf8.RuneCountInString(word) < 2 || strings.ContainsAny(word, "_0123456789") || hasInnerUpper(word) {
+			continue
+		}
+		words = append(words, word)
+	}
+	return words
+}
+
+// hasInnerUpper reports whether a word has upper case letters after the first letter, like acronyms or iPhone
+func hasInnerUpper(word string) bool {
+	for i, r := range word {
+		if i > 0 && unicode.IsUpper(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSpelling checks a word. Words with apostrophes like l'homme are also accepted if all parts are correct.
+func checkSpelling(dictionary *spelling.Dictionary, word string) bool {
+	word = strings.ReplaceAll(word, "’", "'")
+	if dictionary.Check(word) {
+		return true
+	}
+	if !strings.Contains(word, "'") {
+		return false
+	}
+
+	for _, part := range strings.Split(word, "'") {
+		if utf8.RuneCountInString(part) > 2 && !dictionary.Check(part) {
+			return false
+		}
+	}
+	return true
+}
+
+// wordColumn returns the 1-based column of the word in the value of the line
+func wordColumn(line localizable.Line, word string) int {
+	_, value, ok := strings.Cut(line.Text, "=")
+	if !ok {
+		return line.Column()
+	}
+	index := strings.Index(value, word)
+	if index < 0 {
+		return line.Column()
+	}
+	return utf8.RuneCountInString(line.Text[:len(line.Text)-len(value)+index]) + 1
+}
@@ -0,0 +1,155 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/phillippbertram/xc-strings/internal/githistory"
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+// StaleRule reports translations whose base value changed after the translation was last changed,
+// according to the git history of the files. Files which are not tracked by git are skipped.
+type StaleRule struct{}
+
+func init() {
+	Register(StaleRule{})
+}
+
+func (StaleRule) ID() string { return "stale" }
+func (StaleRule) Description() string {
+	return "Translations must be updated when their base value changes"
+}
+func (StaleRule) DefaultSeverity() Severity { return SeverityWarning }
+func (StaleRule) OptIn() bool               { return true }
+func (StaleRule) RequiresBase() bool        { return true }
+
+func (StaleRule) Check(ctx *Context) ([]Diagnostic, error) {
+	if ctx.Set == nil {
+		return nil, nil
+	}
+
+	var diagnostics []Diagnostic
+	for _, table := range ctx.Set.Tables {
+		base := table.Base()
+		if base == nil || !githistory.IsRepository(filepath.Dir(base.File.Path)) {
+			continue
+		}
+		baseChanges, err := valueChanges(base.File)
+		if err != nil {
+			return nil, err
+		}
+
+		baseEntries := base.Entries()
+
+		// entries of the versions of the base file by commit, nil if the version cannot be read
+		versions := make(map[string]map[string]*localizable.Line)
+		baseAt := func(commit string) map[string]*localizable.Line {
+			if entries, ok := versions[commit]; ok {
+				return entries
+			}
+			var entries map[string]*localizable.Line
+			if content, err := githistory.Show(commit, base.File.Path); err == nil {
+				if version, err := localizable.ParseStringsFile(base.File.Path, content); err == nil {
+					entries = (&localizable.Locale{File: version}).Entries()
+				}
+			}
+			versions[commit] = entries
+			return entries
+		}
+
+		for _, translation := range table.Translations() {
+			changes, err := valueChanges(translation.File)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, line := range translation.File.Lines {
+				baseEntry := baseEntries[line.Key]
+				if line.Key == "" || baseEntry == nil {
+					continue
+				}
+
+				// translations changed in the working tree are considered up to date
+				change, ok := changes[line.Key]
+				if !ok {
+					continue
+				}
+
+				// the base value at the time of the translation, keys added to the base later are not stale
+				oldEntry := baseAt(change.Hash)[line.Key]
+				if oldEntry == nil || oldEntry.Value == baseEntry.Value {
+					continue
+				}
+
+				message := fmt.Sprintf("value of key `%s` is stale, the base value changed from \"%s\" to \"%s\"", line.Key, oldEntry.Value, baseEntry.Value)
+				if baseChange, ok := baseChanges[line.Key]; ok {
+					message += fmt.Sprintf(" in %s (%s)", baseChange.ShortHash(), baseChange.Time.Format("2006-01-02"))
+				}
+				diagnostics = append(diagnostics, Diagnostic{
+					Path:    translation.File.Path,
+					Line:    line.LineNumber,
+					Column:  line.Column(),
+					Key:     line.Key,
+					Message: message,
+				})
+			}
+		}
+	}
+	return diagnostics, nil
+}
+
+// valueChanges returns the commit which last changed the value of each key, by comparing the parsed versions of the file,
+// so that sorting or reformatting the file does not hide changes. Keys whose value differs from the last committed
+// version are left out, since they have been changed in the working tree.
+func valueChanges(file *localizable.StringsFile) (map[string]githistory.Commit, error) {
+	commits, err := githistory.Log(file.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]githistory.Commit)
+	// the current values of the keys whose last change has not been found yet
+	pending := entryValues(file)
+	var newer *githistory.Commit
+	for i, commit := range commits {
+		content, err := githistory.Show(commit.Hash, file.Path)
+		if err != nil {
+			return nil, err
+		}
+		version, err := localizable.ParseStringsFile(file.Path, content)
+		if err != nil {
+			return nil, err
+		}
+
+		values := entryValues(version)
+		for key, value := range pending {
+			if oldValue, ok := values[key]; !ok || oldValue != value {
+				if newer != nil {
+					changes[key] = *newer
+				}
+				delete(pending, key)
+			}
+		}
+		newer = &commits[i]
+	}
+
+	// values which have not changed since the file was added
+	if newer != nil {
+		for key := range pending {
+			changes[key] = *newer
+		}
+	}
+	return changes, nil
+}
+
+// entryValues returns the value of each key, the last definition wins just like at runtime
+func entryValues(file *localizable.StringsFile) map[string]string {
+	values := make(map[string]string)
+	for _, line := range file.Lines {
+		if line.Key != "" {
+			values[line.Key] = line.Value
+		}
+	}
+	return values
+}
@@ -0,0 +1,41 @@
+package rules
+
+import "github.com/phillippbertram/xc-strings/internal/localizable"
+
+// SyntaxRule reports syntax errors like missing semicolons, which the line based parser tolerates but Xcode rejects
+type SyntaxRule struct{}
+
+func init() {
+	Register(SyntaxRule{})
+}
+
+func (SyntaxRule) ID() string { return "syntax" }
+func (SyntaxRule) Description() string {
+	return "Files must be valid .strings files"
+}
+func (SyntaxRule) DefaultSeverity() Severity { return SeverityError }
+func (SyntaxRule) OptIn() bool               { return true }
+func (SyntaxRule) fixPhase() int             { return fixPhaseSyntax }
+
+func (SyntaxRule) Check(ctx *Context) ([]Diagnostic, error) {
+	var diagnostics []Diagnostic
+	for _, file := range ctx.Files {
+		for _, syntaxError := range file.SyntaxErrors() {
+			diagnostics = append(diagnostics, Diagnostic{
+				Path:    file.Path,
+				Line:    syntaxError.Line,
+				Column:  syntaxError.Column,
+				Message: syntaxError.Message,
+			})
+		}
+	}
+	return diagnostics, nil
+}
+
+// Fix repairs missing semicolons at the end of a line, unquoted keys and strings missing the closing quote
+// before the semicolon. Other errors need a decision what was meant.
+func (r SyntaxRule) Fix(ctx *Context, file *localizable.StringsFile) int {
+	return file.RepairSyntax(func(syntaxError localizable.SyntaxError) bool {
+		return !file.IsSuppressed(r.ID(), syntaxError.Line)
+	})
+}
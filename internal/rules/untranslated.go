@@ -0,0 +1,103 @@
+package rules
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+// UntranslatedRule reports translations whose value is identical to the base value,
+// like English texts copied into de.lproj as placeholder.
+type UntranslatedRule struct{}
+
+// UntranslatedOptions are the options of the untranslated rule in .xcstrings.yml:
+//
+//	untranslated:
+//	  severity: warning
+//	  keys: ["brand_*"]
+//	  terms: ["OK", "Email"]
+//	  locales:
+//	    de: { terms: ["Login"] }
+type UntranslatedOptions struct {
+	Keys    []string                       `yaml:"keys"`  // glob patterns for keys whose value may be identical
+	Terms   []string                       `yaml:"terms"` // values which may be identical, compared case-insensitively
+	Locales map[string]UntranslatedOptions `yaml:"locales"`
+}
+
+func init() {
+	Register(UntranslatedRule{})
+}
+
+func (UntranslatedRule) ID() string { return "untranslated" }
+func (UntranslatedRule) Description() string {
+	return "Translations must not be identical to the base value"
+}
+func (UntranslatedRule) DefaultSeverity() Severity { return SeverityWarning }
+func (UntranslatedRule) OptIn() bool               { return true }
+func (UntranslatedRule) RequiresBase() bool        { return true }
+
+func (r UntranslatedRule) Check(ctx *Context) ([]Diagnostic, error) {
+	if ctx.Set == nil {
+		return nil, nil
+	}
+
+	var options UntranslatedOptions
+	if err := ctx.RuleOptions(r.ID(), &options); err != nil {
+		return nil, err
+	}
+
+	var diagnostics []Diagnostic
+	forEachTranslatedEntry(ctx, func(_ *localizable.Table, base, translation *localizable.Locale, key string, baseEntry, entry *localizable.Line) {
+		// regional variants like en-GB may share most values with en
+		if language(translation.Code) == language(base.Code) {
+			return
+		}
+		if entry.Value != baseEntry.Value || !hasLetters(entry.Value) {
+			return
+		}
+		if options.allows(key, entry.Value) || options.Locales[translation.Code].allows(key, entry.Value) {
+			return
+		}
+
+		diagnostics = append(diagnostics, Diagnostic{
+			Path:    translation.File.Path,
+			Line:    entry.LineNumber,
+			Column:  entry.Column(),
+			Key:     key,
+			Message: fmt.Sprintf("value of key `%s` is identical to the base value \"%s\"", key, entry.Value),
+		})
+	})
+	return diagnostics, nil
+}
+
+// allows reports whether the key or the value is on the allowlist
+func (o UntranslatedOptions) allows(key string, value string) bool {
+	for _, pattern := range o.Keys {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+	for _, term := range o.Terms {
+		if strings.EqualFold(strings.TrimSpace(value), strings.TrimSpace(term)) {
+			return true
+		}
+	}
+	return false
+}
+
+// language returns the language of a locale code, e.g. "en" for "en-GB"
+func language(code string) string {
+	code, _, _ = strings.Cut(strings.ReplaceAll(code, "_", "-"), "-")
+	return strings.ToLower(code)
+}
+
+// hasLetters reports whether the value contains any letter. Values like "%@" or "42" need no translation.
+func hasLetters(value string) bool {
+	for _, specifier := range localizable.ParseFormatSpecifiers(value) {
+		value = strings.Replace(value, specifier.Text, "", 1)
+	}
+	return strings.IndexFunc(value, unicode.IsLetter) >= 0
+}
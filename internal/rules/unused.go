@@ -0,0 +1,45 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/phillippbertram/xc-strings/internal"
+)
+
+// UnusedRule reports keys of the base files which are not used in any Swift file
+type UnusedRule struct{}
+
+func init() {
+	Register(UnusedRule{})
+}
+
+func (UnusedRule) ID() string                { return "unused" }
+func (UnusedRule) Description() string       { return "Keys of the base files must be used in Swift code" }
+func (UnusedRule) DefaultSeverity() Severity { return SeverityWarning }
+func (UnusedRule) RequiresBase() bool        { return true }
+
+func (UnusedRule) Check(ctx *Context) ([]Diagnostic, error) {
+	if ctx.Set == nil {
+		return nil, nil
+	}
+
+	var diagnostics []Diagnostic
+	for _, file := range ctx.Set.BaseFiles() {
+		if !file.IsReferencedInCode() {
+			continue
+		}
+
+		unusedKeys := internal.FindUnusedKeysInSwiftFiles(ctx.SourcePaths, file.GetAllKeys(), ctx.IgnorePatterns)
+		for _, key := range unusedKeys {
+			line := file.GetLinesForKey(key)[0]
+			diagnostics = append(diagnostics, Diagnostic{
+				Path:    file.Path,
+				Line:    line.LineNumber,
+				Column:  line.Column(),
+				Key:     key,
+				Message: fmt.Sprintf("unused key `%s`", key),
+			})
+		}
+	}
+	return diagnostics, nil
+}
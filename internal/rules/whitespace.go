@@ -0,0 +1,66 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/phillippbertram/xc-strings/internal/localizable"
+)
+
+// WhitespaceRule reports values with leading or trailing whitespace and double spaces
+type WhitespaceRule struct{}
+
+var doubleSpaceRegex = regexp.MustCompile(`  +`)
+
+func init() {
+	Register(WhitespaceRule{})
+}
+
+func (WhitespaceRule) ID() string { return "whitespace" }
+func (WhitespaceRule) Description() string {
+	return "Values must not have leading, trailing or double spaces"
+}
+func (WhitespaceRule) DefaultSeverity() Severity { return SeverityWarning }
+func (WhitespaceRule) OptIn() bool               { return true }
+
+func (WhitespaceRule) Check(ctx *Context) ([]Diagnostic, error) {
+	var diagnostics []Diagnostic
+	for _, file := range ctx.Files {
+		for _, line := range file.Lines {
+			if line.Key == "" {
+				continue
+			}
+
+			value := line.RawValue()
+			var problems []string
+			if value != strings.TrimLeft(value, " \t") {
+				problems = append(problems, "leading whitespace")
+			}
+			if value != strings.TrimRight(value, " \t") {
+				problems = append(problems, "trailing whitespace")
+			}
+			if strings.Contains(strings.TrimSpace(value), "  ") {
+				problems = append(problems, "double spaces")
+			}
+
+			for _, problem := range problems {
+				diagnostics = append(diagnostics, Diagnostic{
+					Path:    file.Path,
+					Line:    line.LineNumber,
+					Column:  line.Column(),
+					Key:     line.Key,
+					Message: fmt.Sprintf("value of key `%s` has %s", line.Key, problem),
+				})
+			}
+		}
+	}
+	return diagnostics, nil
+}
+
+// Fix trims the values and replaces double spaces by a single space
+func (r WhitespaceRule) Fix(ctx *Context, file *localizable.StringsFile) int {
+	return fixRawValues(r.ID(), file, func(value string) string {
+		return doubleSpaceRegex.ReplaceAllString(strings.Trim(value, " \t"), " ")
+	})
+}
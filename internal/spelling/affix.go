@@ -0,0 +1,198 @@
+package spelling
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// flagFormat is the encoding of the flags in .aff and .dic files, set with the FLAG option
+type flagFormat string
+
+const (
+	flagFormatChar flagFormat = ""     // single characters, the default
+	flagFormatLong flagFormat = "long" // two characters
+	flagFormatNum  flagFormat = "num"  // decimal numbers separated by commas
+	flagFormatUTF8 flagFormat = "UTF-8"
+)
+
+// affix is a single prefix or suffix rule like `SFX D y ied [^aeiou]y`
+type affix struct {
+	flag      string
+	suffix    bool
+	cross     bool // may be combined with affixes of the other kind
+	strip     string
+	add       string
+	condition *regexp.Regexp // nil if the affix applies to every stem
+}
+
+// matches reports whether the affix can be applied to the stem
+func (a *affix) matches(stem string) bool {
+	return a.condition == nil || a.condition.MatchString(stem)
+}
+
+// affixFile holds the options of an .aff file which are used for checking and suggesting words
+type affixFile struct {
+	flagFormat     flagFormat
+	aliases        [][]string // flag sets of AF, referenced by their 1-based index in .dic files
+	prefixes       []*affix
+	suffixes       []*affix
+	try            string
+	replacements   [][2]string
+	ignore         string
+	keepCase       string
+	noSuggest      string
+	forbidden      string
+	needAffix      string
+	onlyInCompound string
+	compoundFlag   string
+	compoundBegin  string
+	compoundMiddle string
+	compoundEnd    string
+	compoundMin    int
+}
+
+// parseAffixFile parses the content of an .aff file. Options which are not supported are ignored.
+func parseAffixFile(content []byte) (*affixFile, error) {
+	aff := &affixFile{compoundMin: 3}
+	cross := make(map[string]bool) // whether each affix class may be combined with affixes of the other kind
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		switch fields[0] {
+		case "FLAG":
+			aff.flagFormat = flagFormat(fields[1])
+		case "AF":
+			// the first AF line is the number of aliases
+			if _, err := strconv.Atoi(fields[1]); err == nil && len(aff.aliases) == 0 && len(fields) == 2 {
+				continue
+			}
+			aff.aliases = append(aff.aliases, aff.splitFlags(fields[1]))
+		case "TRY":
+			aff.try = fields[1]
+		case "IGNORE":
+			aff.ignore = fields[1]
+		case "REP":
+			if len(fields) >= 3 {
+				aff.replacements = append(aff.replacements, [2]string{unescapeReplacement(fields[1]), unescapeReplacement(fields[2])})
+			}
+		case "KEEPCASE":
+			aff.keepCase = fields[1]
+		case "NOSUGGEST":
+			aff.noSuggest = fields[1]
+		case "FORBIDDENWORD":
+			aff.forbidden = fields[1]
+		case "NEEDAFFIX", "PSEUDOROOT":
+			aff.needAffix = fields[1]
+		case "ONLYINCOMPOUND":
+			aff.onlyInCompound = fields[1]
+		case "COMPOUNDFLAG":
+			aff.compoundFlag = fields[1]
+		case "COMPOUNDBEGIN":
+			aff.compoundBegin = fields[1]
+		case "COMPOUNDMIDDLE":
+			aff.compoundMiddle = fields[1]
+		case "COMPOUNDEND", "COMPOUNDLAST":
+			aff.compoundEnd = fields[1]
+		case "COMPOUNDMIN":
+			if min, err := strconv.Atoi(fields[1]); err == nil && min > 0 {
+				aff.compoundMin = min
+			}
+		case "PFX", "SFX":
+			if err := aff.parseAffix(fields, cross); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+		}
+	}
+	return aff, scanner.Err()
+}
+
+// parseAffix parses the header `SFX flag cross count` or a rule `SFX flag strip add[/flags] [condition]` of an affix class.
+// The first line of a class is its header.
+func (aff *affixFile) parseAffix(fields []string, cross map[string]bool) error {
+	if len(fields) < 4 {
+		return fmt.Errorf("invalid %s line", fields[0])
+	}
+
+	class := fields[0] + fields[1]
+	if _, ok := cross[class]; !ok {
+		cross[class] = fields[2] == "Y"
+		return nil
+	}
+
+	suffix := fields[0] == "SFX"
+	a := &affix{flag: fields[1], suffix: suffix, cross: cross[class]}
+	if fields[2] != "0" {
+		a.strip = fields[2]
+	}
+	add, _, _ := strings.Cut(fields[3], "/") // continuation classes are not supported
+	if add != "0" {
+		a.add = add
+	}
+
+	if len(fields) > 4 && fields[4] != "." {
+		pattern := fields[4] + "$"
+		if !suffix {
+			pattern = "^" + fields[4]
+		}
+		condition, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid condition '%s': %w", fields[4], err)
+		}
+		a.condition = condition
+	}
+
+	if suffix {
+		aff.suffixes = append(aff.suffixes, a)
+	} else {
+		aff.prefixes = append(aff.prefixes, a)
+	}
+	return nil
+}
+
+// parseFlags returns the flags of a word, which may be the index of an alias
+func (aff *affixFile) parseFlags(flags string) []string {
+	if len(aff.aliases) > 0 {
+		if index, err := strconv.Atoi(flags); err == nil && index > 0 && index <= len(aff.aliases) {
+			return aff.aliases[index-1]
+		}
+	}
+	return aff.splitFlags(flags)
+}
+
+// splitFlags splits flags according to the flag format
+func (aff *affixFile) splitFlags(flags string) []string {
+	var result []string
+	switch aff.flagFormat {
+	case flagFormatLong:
+		for i := 0; i+1 < len(flags); i += 2 {
+			result = append(result, flags[i:i+2])
+		}
+	case flagFormatNum:
+		result = strings.Split(flags, ",")
+	default:
+		for len(flags) > 0 {
+			_, size := utf8.DecodeRuneInString(flags)
+			result = append(result, flags[:size])
+			flags = flags[size:]
+		}
+	}
+	return result
+}
+
+// unescapeReplacement converts the underscores of REP patterns into spaces
+func unescapeReplacement(s string) string {
+	return strings.ReplaceAll(s, "_", " ")
+}
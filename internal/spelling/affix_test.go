@@ -0,0 +1,63 @@
+package spelling
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		aff   string
+		flags string
+		want  []string
+	}{
+		{"characters", "", "SC", []string{"S", "C"}},
+		{"UTF-8 characters", "FLAG UTF-8\n", "ÜS", []string{"Ü", "S"}},
+		{"long", "FLAG long\n", "AaBb", []string{"Aa", "Bb"}},
+		{"numbers", "FLAG num\n", "101,7", []string{"101", "7"}},
+		{"alias", "AF 2\nAF SC\nAF D\n", "1", []string{"S", "C"}},
+		{"alias out of range", "AF 1\nAF SC\n", "2", []string{"2"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			aff, err := parseAffixFile([]byte(test.aff))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if flags := aff.parseFlags(test.flags); !reflect.DeepEqual(flags, test.want) {
+				t.Errorf("parseFlags(%q) = %q, want %q", test.flags, flags, test.want)
+			}
+		})
+	}
+}
+
+func TestParseAffix(t *testing.T) {
+	aff, err := parseAffixFile([]byte(`PFX U Y 1
+PFX U 0 un .
+SFX D N 2
+SFX D y ied [^aeiou]y
+SFX D 0 ed/X [^y]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(aff.prefixes) != 1 || len(aff.suffixes) != 2 {
+		t.Fatalf("got %d prefixes and %d suffixes, want 1 and 2", len(aff.prefixes), len(aff.suffixes))
+	}
+	if prefix := aff.prefixes[0]; prefix.add != "un" || prefix.strip != "" || !prefix.cross || prefix.condition != nil {
+		t.Errorf("unexpected prefix %+v", prefix)
+	}
+	if suffix := aff.suffixes[0]; suffix.add != "ied" || suffix.strip != "y" || suffix.cross || !suffix.matches("try") || suffix.matches("play") {
+		t.Errorf("unexpected suffix %+v", suffix)
+	}
+	if suffix := aff.suffixes[1]; suffix.add != "ed" {
+		t.Errorf("expected continuation classes to be ignored, got %q", suffix.add)
+	}
+
+	if _, err := parseAffixFile([]byte("SFX D N 1\nSFX D 0 ed [\n")); err == nil {
+		t.Error("expected an error for an invalid condition")
+	}
+}
@@ -0,0 +1,274 @@
+package spelling
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Dictionary is a Hunspell dictionary read from a .dic file and the .aff file next to it.
+// It supports prefixes, suffixes and simple compounds, but not every option of Hunspell.
+type Dictionary struct {
+	aff   *affixFile
+	words map[string][]string // flags by word
+}
+
+var encodingRegex = regexp.MustCompile(`(?m)^SET\s+(\S+)`)
+
+// Load reads the dictionary at path, which is the .dic file of the dictionary
+func Load(path string) (*Dictionary, error) {
+	affContent, err := os.ReadFile(strings.TrimSuffix(path, ".dic") + ".aff")
+	if err != nil {
+		return nil, err
+	}
+	dicContent, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if match := encodingRegex.FindSubmatch(affContent); match != nil {
+		switch encoding := strings.ToUpper(string(match[1])); encoding {
+		case "UTF-8":
+		case "ISO8859-1", "ISO-8859-1", "ISO8859-15", "ISO-8859-15":
+			affContent, dicContent = latin1ToUTF8(affContent), latin1ToUTF8(dicContent)
+		default:
+			return nil, fmt.Errorf("unsupported encoding %s of dictionary %s, convert it to UTF-8", encoding, path)
+		}
+	}
+
+	aff, err := parseAffixFile(affContent)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", strings.TrimSuffix(path, ".dic")+".aff", err)
+	}
+
+	d := &Dictionary{aff: aff, words: make(map[string][]string)}
+	scanner := bufio.NewScanner(bytes.NewReader(dicContent))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	first := true
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if first {
+			// the first line is the number of words
+			first = false
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// morphological fields follow after whitespace
+		if i := strings.IndexAny(line, " \t"); i >= 0 {
+			line = line[:i]
+		}
+		word, flags := splitEntry(line)
+		d.words[word] = append(d.words[word], aff.parseFlags(flags)...)
+	}
+	return d, scanner.Err()
+}
+
+// splitEntry splits a .dic entry like `house/SM` into word and flags. Slashes in words are escaped.
+func splitEntry(entry string) (string, string) {
+	for i := 0; i < len(entry); i++ {
+		switch entry[i] {
+		case '\\':
+			i++
+		case '/':
+			return strings.ReplaceAll(entry[:i], `\/`, "/"), entry[i+1:]
+		}
+	}
+	return strings.ReplaceAll(entry, `\/`, "/"), ""
+}
+
+func latin1ToUTF8(content []byte) []byte {
+	var buffer bytes.Buffer
+	buffer.Grow(len(content))
+	for _, b := range content {
+		buffer.WriteRune(rune(b))
+	}
+	return buffer.Bytes()
+}
+
+// AddWord adds a word without flags, like the words of a project word list
+func (d *Dictionary) AddWord(word string) {
+	if _, ok := d.words[word]; !ok {
+		d.words[word] = nil
+	}
+}
+
+// Check reports whether the word is spelled correctly. Capitalized and upper case words are also accepted
+// in lower case, like at the beginning of a sentence.
+func (d *Dictionary) Check(word string) bool {
+	for _, c := range d.aff.ignore {
+		word = strings.ReplaceAll(word, string(c), "")
+	}
+	if word == "" {
+		return true
+	}
+
+	for i, variant := range caseVariants(word) {
+		if d.checkWord(variant, i > 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// caseVariants returns the word, and its lower case and capitalized forms if it is capitalized or upper case
+func caseVariants(word string) []string {
+	variants := []string{word}
+	first, size := utf8.DecodeRuneInString(word)
+	if !unicode.IsUpper(first) {
+		return variants
+	}
+
+	lower := strings.ToLower(word)
+	capitalized := string(first) + strings.ToLower(word[size:])
+	if word != capitalized {
+		variants = append(variants, capitalized)
+	}
+	return append(variants, lower)
+}
+
+func (d *Dictionary) checkWord(word string, caseChanged bool) bool {
+	if flags, ok := d.words[word]; ok {
+		switch {
+		case d.hasFlag(flags, d.aff.forbidden):
+			return false
+		case caseChanged && d.hasFlag(flags, d.aff.keepCase):
+		case !d.hasFlag(flags, d.aff.needAffix) && !d.hasFlag(flags, d.aff.onlyInCompound):
+			return true
+		}
+	}
+	return d.checkAffixed(word) || d.checkCompound(word)
+}
+
+// checkAffixed reports whether the word is a word of the dictionary with a prefix, a suffix or both
+func (d *Dictionary) checkAffixed(word string) bool {
+	for _, sfx := range d.aff.suffixes {
+		stem, ok := removeAffix(word, sfx)
+		if !ok {
+			continue
+		}
+		if d.rootHasFlags(stem, sfx.flag) {
+			return true
+		}
+
+		if !sfx.cross {
+			continue
+		}
+		for _, pfx := range d.aff.prefixes {
+			if root, ok := removeAffix(stem, pfx); ok && pfx.cross && d.rootHasFlags(root, sfx.flag, pfx.flag) {
+				return true
+			}
+		}
+	}
+
+	for _, pfx := range d.aff.prefixes {
+		if root, ok := removeAffix(word, pfx); ok && d.rootHasFlags(root, pfx.flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeAffix returns the stem of the word without the affix, if the affix can produce the word
+func removeAffix(word string, a *affix) (string, bool) {
+	var stem string
+	if a.suffix {
+		if !strings.HasSuffix(word, a.add) || len(word) == len(a.add) {
+			return "", false
+		}
+		stem = word[:len(word)-len(a.add)] + a.strip
+	} else {
+		if !strings.HasPrefix(word, a.add) || len(word) == len(a.add) {
+			return "", false
+		}
+		stem = a.strip + word[len(a.add):]
+	}
+	return stem, a.matches(stem)
+}
+
+// rootHasFlags reports whether the root is a word of the dictionary with all flags, which may be affixed
+func (d *Dictionary) rootHasFlags(root string, flags ...string) bool {
+	rootFlags, ok := d.words[root]
+	if !ok || d.hasFlag(rootFlags, d.aff.forbidden) || d.hasFlag(rootFlags, d.aff.onlyInCompound) {
+		return false
+	}
+	for _, flag := range flags {
+		if !d.hasFlag(rootFlags, flag) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkCompound reports whether the word consists of words which may be compounded
+func (d *Dictionary) checkCompound(word string) bool {
+	if d.aff.compoundFlag == "" && d.aff.compoundBegin == "" {
+		return false
+	}
+	return d.checkCompoundParts([]rune(word), 0)
+}
+
+func (d *Dictionary) checkCompoundParts(runes []rune, position int) bool {
+	min := d.aff.compoundMin
+	for i := min; i <= len(runes)-min; i++ {
+		part, rest := string(runes[:i]), runes[i:]
+		if position == 0 && !d.isCompoundPart(part, d.aff.compoundBegin) {
+			continue
+		}
+		if position > 0 && !d.isCompoundPart(part, d.aff.compoundMiddle) {
+			continue
+		}
+
+		if d.isCompoundPart(string(rest), d.aff.compoundEnd) || (position < 3 && d.checkCompoundParts(rest, position+1)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCompoundPart reports whether the part may be compounded at a position with the given flag.
+// Parts after the first one are also looked up capitalized, because nouns are capitalized in some languages.
+// Parts may have a suffix.
+func (d *Dictionary) isCompoundPart(part string, positionFlag string) bool {
+	variants := []string{part}
+	if first, size := utf8.DecodeRuneInString(part); unicode.IsLower(first) {
+		variants = append(variants, string(unicode.ToUpper(first))+part[size:])
+	}
+
+	for _, variant := range variants {
+		if d.isCompoundWord(variant, positionFlag) {
+			return true
+		}
+		// inflected parts like "Hausbooten"
+		for _, sfx := range d.aff.suffixes {
+			if stem, ok := removeAffix(variant, sfx); ok && d.isCompoundWord(stem, positionFlag) && d.hasFlag(d.words[stem], sfx.flag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (d *Dictionary) isCompoundWord(word string, positionFlag string) bool {
+	flags, ok := d.words[word]
+	return ok && (d.hasFlag(flags, d.aff.compoundFlag) || d.hasFlag(flags, positionFlag)) && !d.hasFlag(flags, d.aff.forbidden)
+}
+
+func (d *Dictionary) hasFlag(flags []string, flag string) bool {
+	if flag == "" {
+		return false
+	}
+	for _, f := range flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
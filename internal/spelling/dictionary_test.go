@@ -0,0 +1,146 @@
+package spelling
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testAff = `SET UTF-8
+TRY esianrtolcdugmphbyfvkwzxjq
+FORBIDDENWORD !
+KEEPCASE K
+NEEDAFFIX N
+NOSUGGEST X
+ONLYINCOMPOUND O
+COMPOUNDFLAG C
+COMPOUNDMIN 3
+REP 1
+REP f ph
+
+PFX U Y 1
+PFX U 0 un .
+
+PFX R N 1
+PFX R 0 re .
+
+SFX S Y 2
+SFX S y ies [^aeiou]y
+SFX S 0 s [^y]
+
+SFX D N 1
+SFX D 0 ed .
+`
+
+const testDic = `15
+house/SC
+boat/SC
+boats/!
+city/S
+lock/USD
+write/RS
+happy
+foo/!
+bar/NS
+ok/K
+phone
+xyzzy/X
+fugen/O
+path\/name
+word/S	po:noun
+`
+
+// writeTestDictionary writes the .aff and .dic files to a temporary directory and returns the path of the .dic file
+func writeTestDictionary(t *testing.T, aff string, dic string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "test.aff"), []byte(aff), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "test.dic"), []byte(dic), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, "test.dic")
+}
+
+func loadTestDictionary(t *testing.T, aff string, dic string) *Dictionary {
+	t.Helper()
+	d, err := Load(writeTestDictionary(t, aff, dic))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestCheck(t *testing.T) {
+	d := loadTestDictionary(t, testAff, testDic)
+
+	tests := []struct {
+		name    string
+		word    string
+		correct bool
+	}{
+		{"root", "house", true},
+		{"unknown word", "hause", false},
+		{"suffix", "houses", true},
+		{"suffix with strip and condition", "cities", true},
+		{"suffix condition not met", "citys", false},
+		{"suffix without flag", "happys", false},
+		{"prefix", "unlock", true},
+		{"prefix and suffix with cross product", "unlocks", true},
+		{"suffix without cross product", "unlocked", false},
+		{"prefix without cross product", "rewrites", false},
+		{"prefix only", "rewrite", true},
+		{"forbidden word", "foo", false},
+		{"forbidden affixed form", "boats", false},
+		{"need affix root", "bar", false},
+		{"need affix with suffix", "bars", true},
+		{"capitalized", "House", true},
+		{"upper case", "HOUSE", true},
+		{"keep case", "ok", true},
+		{"keep case capitalized", "Ok", false},
+		{"keep case upper case", "OK", false},
+		{"compound", "houseboat", true},
+		{"compound with suffix", "houseboats", true},
+		{"compound of words without compound flag", "happycity", false},
+		{"only in compound alone", "fugen", false},
+		{"escaped slash", "path/name", true},
+		{"morphological fields", "words", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if correct := d.Check(test.word); correct != test.correct {
+				t.Errorf("Check(%q) = %v, want %v", test.word, correct, test.correct)
+			}
+		})
+	}
+}
+
+func TestAddWord(t *testing.T) {
+	d := loadTestDictionary(t, testAff, testDic)
+	if d.Check("Acme") {
+		t.Fatal("expected Acme to be unknown")
+	}
+	d.AddWord("Acme")
+	if !d.Check("Acme") || !d.Check("ACME") {
+		t.Error("expected added word to be spelled correctly")
+	}
+	// flags of dictionary words are kept
+	d.AddWord("foo")
+	if d.Check("foo") {
+		t.Error("expected forbidden word to stay forbidden")
+	}
+}
+
+func TestLoadEncoding(t *testing.T) {
+	// café in ISO-8859-1
+	d := loadTestDictionary(t, "SET ISO8859-1\n", "1\ncaf\xe9\n")
+	if !d.Check("café") {
+		t.Error("expected ISO-8859-1 dictionary to be converted to UTF-8")
+	}
+
+	if _, err := Load(writeTestDictionary(t, "SET KOI8-R\n", "0\n")); err == nil {
+		t.Error("expected an error for an unsupported encoding")
+	}
+}
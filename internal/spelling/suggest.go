@@ -0,0 +1,103 @@
+package spelling
+
+import (
+	"strings"
+)
+
+// maxSuggestions is the maximum number of suggestions for a misspelled word
+const maxSuggestions = 5
+
+// defaultTry are the characters tried for suggestions if the .aff file has no TRY option
+const defaultTry = "esianrtolcdugmphbyfvkwzxjq"
+
+// Suggest returns correctly spelled words similar to the misspelled word, most likely first.
+// Suggestions replace common misspellings of the REP option, and delete, swap, replace or insert a character.
+func (d *Dictionary) Suggest(word string) []string {
+	var suggestions []string
+	seen := map[string]bool{word: true}
+	add := func(candidate string) bool {
+		if seen[candidate] {
+			return false
+		}
+		seen[candidate] = true
+		if d.isSuggestible(candidate) {
+			suggestions = append(suggestions, candidate)
+		}
+		return len(suggestions) >= maxSuggestions
+	}
+
+	for _, replacement := range d.aff.replacements {
+		for i := 0; ; {
+			index := strings.Index(word[i:], replacement[0])
+			if index < 0 {
+				break
+			}
+			index += i
+			if add(word[:index] + replacement[1] + word[index+len(replacement[0]):]) {
+				return suggestions
+			}
+			i = index + 1
+		}
+	}
+
+	try := d.aff.try
+	if try == "" {
+		try = defaultTry
+	}
+	runes := []rune(word)
+
+	for _, candidate := range edits(runes, []rune(try)) {
+		if add(candidate) {
+			return suggestions
+		}
+	}
+
+	// two words written together
+	for i := 1; i < len(runes); i++ {
+		first, second := string(runes[:i]), string(runes[i:])
+		if d.Check(first) && d.Check(second) && add(first+" "+second) {
+			return suggestions
+		}
+	}
+	return suggestions
+}
+
+// isSuggestible reports whether the candidate is spelled correctly and not excluded from suggestions.
+// Words with a space are checked per word.
+func (d *Dictionary) isSuggestible(candidate string) bool {
+	for _, word := range strings.Fields(candidate) {
+		if !d.Check(word) {
+			return false
+		}
+		if flags, ok := d.words[word]; ok && d.hasFlag(flags, d.aff.noSuggest) {
+			return false
+		}
+	}
+	return true
+}
+
+// edits returns all words which differ by a swapped, deleted, replaced or inserted character
+func edits(runes []rune, try []rune) []string {
+	var candidates []string
+	for i := 0; i+1 < len(runes); i++ {
+		swapped := append([]rune{}, runes...)
+		swapped[i], swapped[i+1] = swapped[i+1], swapped[i]
+		candidates = append(candidates, string(swapped))
+	}
+	for i := range runes {
+		candidates = append(candidates, string(runes[:i])+string(runes[i+1:]))
+	}
+	for i := range runes {
+		for _, c := range try {
+			if c != runes[i] {
+				candidates = append(candidates, string(runes[:i])+string(c)+string(runes[i+1:]))
+			}
+		}
+	}
+	for i := 0; i <= len(runes); i++ {
+		for _, c := range try {
+			candidates = append(candidates, string(runes[:i])+string(c)+string(runes[i:]))
+		}
+	}
+	return candidates
+}
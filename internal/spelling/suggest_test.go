@@ -0,0 +1,44 @@
+package spelling
+
+import "testing"
+
+func TestSuggest(t *testing.T) {
+	d := loadTestDictionary(t, testAff, testDic)
+
+	tests := []struct {
+		name  string
+		word  string
+		first string // expected first suggestion, empty if there is none
+	}{
+		{"swapped characters", "hosue", "house"},
+		{"missing character", "hous", "house"},
+		{"extra character", "hoouse", "house"},
+		{"replaced character", "houze", "house"},
+		{"replacement of the aff file", "fone", "phone"},
+		{"two words", "happycity", "happy city"},
+		{"no suggestion for forbidden words", "fooo", ""},
+		{"no suggestion for words excluded from suggestions", "xyzzz", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			suggestions := d.Suggest(test.word)
+			if test.first == "" {
+				if len(suggestions) > 0 {
+					t.Errorf("Suggest(%q) = %q, want no suggestions", test.word, suggestions)
+				}
+				return
+			}
+			if len(suggestions) == 0 || suggestions[0] != test.first {
+				t.Errorf("Suggest(%q) = %q, want %q first", test.word, suggestions, test.first)
+			}
+		})
+	}
+}
+
+func TestSuggestLimit(t *testing.T) {
+	d := loadTestDictionary(t, "TRY abc\n", "6\nab\nac\nba\nbc\nca\ncb\n")
+	if suggestions := d.Suggest("aa"); len(suggestions) > maxSuggestions {
+		t.Errorf("got %d suggestions, want at most %d", len(suggestions), maxSuggestions)
+	}
+}
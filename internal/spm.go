@@ -0,0 +1,163 @@
+package internal
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Target is a unit of Swift code bundled together with its own strings tables,
+// e.g. a Swift package target.
+type Target struct {
+	Name              string
+	Project           string // path of the Package.swift or .xcodeproj the target belongs to
+	DevelopmentRegion string
+	SourcePaths       []string // directories or files containing the Swift sources of the target
+	StringsFiles      []string // .strings files bundled with the target
+	UsesModuleBundle  bool     // resources are looked up via Bundle.module
+}
+
+// Label returns a human readable name of the target including its project
+func (t *Target) Label() string {
+	return t.Name + " (" + t.Project + ")"
+}
+
+var (
+	packageTargetRegex = regexp.MustCompile(`\.(target|executableTarget)\s*\(`)
+	targetNameRegex    = regexp.MustCompile(`\bname\s*:\s*"([^"]+)"`)
+	targetPathRegex    = regexp.MustCompile(`\bpath\s*:\s*"([^"]+)"`)
+)
+
+// FindSwiftPackageTargets finds all Package.swift manifests below root and returns their targets
+// which bundle localized strings.
+func FindSwiftPackageTargets(root string, ignorePatterns []string) ([]*Target, error) {
+	var manifests []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != root && isIgnored(d.Name(), ignorePatterns) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() && d.Name() == "Package.swift" {
+			manifests = append(manifests, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []*Target
+	for _, manifest := range manifests {
+		packageTargets, err := ReadSwiftPackageTargets(manifest)
+		if err != nil {
+			return nil, err
+		}
+		for _, target := range packageTargets {
+			if len(target.StringsFiles) > 0 {
+				targets = append(targets, target)
+			}
+		}
+	}
+
+	return targets, nil
+}
+
+// ReadSwiftPackageTargets reads the regular and executable targets of a Package.swift manifest
+// together with the .strings files found in the .lproj directories of each target.
+func ReadSwiftPackageTargets(packagePath string) ([]*Target, error) {
+	content, err := os.ReadFile(packagePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// packages without resources do not need a default localization
+	defaultLocalization, _ := FindDefaultLocalizationInPackage(packagePath)
+	packageDir := filepath.Dir(packagePath)
+	manifest := string(content)
+
+	var targets []*Target
+	for _, loc := range packageTargetRegex.FindAllStringIndex(manifest, -1) {
+		arguments := callArguments(manifest, loc[1]-1)
+
+		nameMatch := targetNameRegex.FindStringSubmatch(arguments)
+		if nameMatch == nil {
+			continue
+		}
+
+		targetDir := filepath.Join(packageDir, "Sources", nameMatch[1])
+		if pathMatch := targetPathRegex.FindStringSubmatch(arguments); pathMatch != nil {
+			targetDir = filepath.Join(packageDir, pathMatch[1])
+		}
+
+		stringsFiles, err := findLocalizedStringsFiles(targetDir)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		targets = append(targets, &Target{
+			Name:              nameMatch[1],
+			Project:           packagePath,
+			DevelopmentRegion: defaultLocalization,
+			SourcePaths:       []string{targetDir},
+			StringsFiles:      stringsFiles,
+			UsesModuleBundle:  true,
+		})
+	}
+
+	return targets, nil
+}
+
+// findLocalizedStringsFiles returns all .strings files located in .lproj directories below dir
+func findLocalizedStringsFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && filepath.Ext(path) == ".strings" && strings.HasSuffix(filepath.Dir(path), ".lproj") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+// callArguments returns the text between the parenthesis at openIndex and its matching closing parenthesis.
+// Parentheses inside string literals are ignored.
+func callArguments(content string, openIndex int) string {
+	depth := 0
+	inString := false
+	for i := openIndex; i < len(content); i++ {
+		c := content[i]
+		if inString {
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return content[openIndex+1 : i]
+			}
+		}
+	}
+	return content[openIndex+1:]
+}
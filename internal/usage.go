@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// KeyUsage is a lookup of a localization key in Swift code
+type KeyUsage struct {
+	Key    string
+	Path   string
+	Line   int
+	Column int
+	Table  string // table the key is looked up in, empty for Localizable
+	Bundle string // bundle argument as written in code, empty for the main bundle
+}
+
+// TableName returns the name of the table the key is looked up in
+func (u KeyUsage) TableName() string {
+	if u.Table == "" {
+		return "Localizable"
+	}
+	return u.Table
+}
+
+// UsesModuleBundle reports whether the key is looked up in the resource bundle of a Swift package
+func (u KeyUsage) UsesModuleBundle() bool {
+	return u.Bundle == ".module" || u.Bundle == "Bundle.module"
+}
+
+var (
+	// matches lookups like NSLocalizedString("key", ...), String(localized: "key", ...) or Text("key", ...)
+	keyLookupRegex = regexp.MustCompile(`\b(NSLocalizedString|String|LocalizedStringKey|LocalizedStringResource|Text)\(\s*(localized:\s*)?"((?:[^"\\]|\\.)*)"`)
+	bundleArgRegex = regexp.MustCompile(`\bbundle\s*:\s*([\w.]+)`)
+	tableArgRegex  = regexp.MustCompile(`\b(?:tableName|table)\s*:\s*"([^"]+)"`)
+)
+
+// FindKeyUsages finds all localization key lookups with a string literal key in the Swift files of the given paths.
+// Additional usage patterns capture the key in their first group.
+func FindKeyUsages(paths []string, ignorePatterns []string, usagePatterns []*regexp.Regexp) ([]KeyUsage, error) {
+	var usages []KeyUsage
+
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if path != root && isIgnored(d.Name(), ignorePatterns) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() || !strings.HasSuffix(d.Name(), ".swift") {
+				return nil
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			usages = append(usages, findKeyUsagesInSource(path, string(content))...)
+			usages = append(usages, findCustomKeyUsagesInSource(path, string(content), usagePatterns)...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return usages, nil
+}
+
+func findKeyUsagesInSource(path string, content string) []KeyUsage {
+	var usages []KeyUsage
+
+	for _, match := range keyLookupRegex.FindAllStringSubmatchIndex(content, -1) {
+		function := content[match[2]:match[3]]
+		hasLocalizedLabel := match[4] != -1
+
+		// String("...") is a plain string, only String(localized: "...") is a lookup
+		if function == "String" && !hasLocalizedLabel {
+			continue
+		}
+
+		// interpolated strings are not looked up by a literal key
+		key := content[match[6]:match[7]]
+		if strings.Contains(key, `\(`) {
+			continue
+		}
+
+		line, column := position(content, match[0])
+		usage := KeyUsage{
+			Key:    key,
+			Path:   path,
+			Line:   line,
+			Column: column,
+		}
+
+		openIndex := match[0] + len(function)
+		arguments := callArguments(content, openIndex)
+		if tableMatch := tableArgRegex.FindStringSubmatch(arguments); tableMatch != nil {
+			usage.Table = tableMatch[1]
+		}
+		if bundleMatch := bundleArgRegex.FindStringSubmatch(arguments); bundleMatch != nil {
+			usage.Bundle = bundleMatch[1]
+			if usage.Bundle == ".main" || usage.Bundle == "Bundle.main" {
+				usage.Bundle = ""
+			}
+		}
+
+		usages = append(usages, usage)
+	}
+
+	return usages
+}
+
+func findCustomKeyUsagesInSource(path string, content string, usagePatterns []*regexp.Regexp) []KeyUsage {
+	var usages []KeyUsage
+	for _, pattern := range usagePatterns {
+		for _, match := range pattern.FindAllStringSubmatchIndex(content, -1) {
+			if len(match) < 4 || match[2] == -1 {
+				continue
+			}
+			line, column := position(content, match[0])
+			usages = append(usages, KeyUsage{
+				Key:    content[match[2]:match[3]],
+				Path:   path,
+				Line:   line,
+				Column: column,
+			})
+		}
+	}
+	return usages
+}
+
+// position returns the 1-based line and column (in characters) of the byte offset in content
+func position(content string, offset int) (int, int) {
+	lineStart := strings.LastIndex(content[:offset], "\n") + 1
+	line := strings.Count(content[:offset], "\n") + 1
+	return line, utf8.RuneCountInString(content[lineStart:offset]) + 1
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 )
 
@@ -105,6 +106,8 @@ func findPBXProjPath(basePath string) (string, error) {
 	return foundPath, nil
 }
 
+var developmentRegionRegex = regexp.MustCompile(`developmentRegion\s*=\s*"?([^";\s]+)"?\s*;`)
+
 // searches for the developmentRegion in an Xcode project.pbxproj file.
 func findDevelopmentRegionInPbxProj(filepath string) (string, error) {
 	file, err := os.Open(filepath)
@@ -116,17 +119,9 @@ func findDevelopmentRegionInPbxProj(filepath string) (string, error) {
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 		line := scanner.Text()
-		// Look for the developmentRegion key in the file
-		if strings.Contains(line, "developmentRegion") {
-			// Assuming the line format is developmentRegion = en;
-			parts := strings.Split(line, "=")
-			if len(parts) > 1 {
-				// Clean up the parsed region value
-				region := strings.TrimSpace(parts[1])
-				region = strings.Trim(region, ";")
-				region = strings.Trim(region, "\"")
-				return region, nil
-			}
+		// Look for the developmentRegion key in the file, e.g. developmentRegion = en;
+		if match := developmentRegionRegex.FindStringSubmatch(line); match != nil {
+			return match[1], nil
 		}
 	}
 
@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type workspaceItem struct {
+	Location string          `xml:"location,attr"`
+	Groups   []workspaceItem `xml:"Group"`
+	FileRefs []workspaceItem `xml:"FileRef"`
+}
+
+// ReadWorkspaceProjects returns the paths of all .xcodeproj and Swift package directories referenced by an .xcworkspace
+func ReadWorkspaceProjects(workspacePath string) ([]string, error) {
+	content, err := os.ReadFile(filepath.Join(workspacePath, "contents.xcworkspacedata"))
+	if err != nil {
+		return nil, err
+	}
+
+	var workspace workspaceItem
+	if err := xml.Unmarshal(content, &workspace); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", workspacePath, err)
+	}
+
+	containerDir := filepath.Dir(workspacePath)
+	var projects []string
+	var collect func(item workspaceItem, groupDir string)
+	collect = func(item workspaceItem, groupDir string) {
+		for _, group := range item.Groups {
+			collect(group, resolveWorkspaceLocation(group.Location, groupDir, containerDir))
+		}
+		for _, fileRef := range item.FileRefs {
+			path := resolveWorkspaceLocation(fileRef.Location, groupDir, containerDir)
+			if strings.HasSuffix(path, ".xcodeproj") {
+				projects = append(projects, path)
+			} else if _, err := os.Stat(filepath.Join(path, "Package.swift")); err == nil {
+				projects = append(projects, path)
+			}
+		}
+	}
+	collect(workspace, containerDir)
+
+	return projects, nil
+}
+
+// resolveWorkspaceLocation resolves locations like "group:App.xcodeproj" or "container:Packages/Feature"
+func resolveWorkspaceLocation(location string, groupDir string, containerDir string) string {
+	kind, path, found := strings.Cut(location, ":")
+	if !found {
+		return groupDir
+	}
+
+	switch kind {
+	case "group":
+		return filepath.Join(groupDir, path)
+	case "container":
+		return filepath.Join(containerDir, path)
+	case "absolute":
+		return path
+	default: // "self" refers to the workspace itself
+		return groupDir
+	}
+}
+
+// FindTargets finds all targets below root: the targets of the projects referenced by
+// .xcworkspace files, the targets of .xcodeproj files and Swift package targets with localized strings.
+func FindTargets(root string, ignorePatterns []string) ([]*Target, error) {
+	var workspaces, projects []string
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != root && isIgnored(d.Name(), ignorePatterns) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".xcworkspace":
+			workspaces = append(workspaces, path)
+			return filepath.SkipDir
+		case ".xcodeproj":
+			projects = append(projects, path)
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var packageDirs []string
+	for _, workspace := range workspaces {
+		references, err := ReadWorkspaceProjects(workspace)
+		if err != nil {
+			return nil, err
+		}
+		for _, reference := range references {
+			if strings.HasSuffix(reference, ".xcodeproj") {
+				projects = append(projects, reference)
+			} else {
+				packageDirs = append(packageDirs, reference)
+			}
+		}
+	}
+
+	var targets []*Target
+	seen := map[string]bool{}
+	addTargets := func(newTargets []*Target) {
+		for _, target := range newTargets {
+			id := filepath.Clean(target.Project) + "#" + target.Name
+			if !seen[id] {
+				seen[id] = true
+				targets = append(targets, target)
+			}
+		}
+	}
+
+	for _, project := range projects {
+		projectTargets, err := ReadXcodeProjectTargets(project)
+		if err != nil {
+			return nil, err
+		}
+		addTargets(projectTargets)
+	}
+
+	for _, dir := range append([]string{root}, packageDirs...) {
+		packageTargets, err := FindSwiftPackageTargets(dir, ignorePatterns)
+		if err != nil {
+			return nil, err
+		}
+		addTargets(packageTargets)
+	}
+
+	return targets, nil
+}